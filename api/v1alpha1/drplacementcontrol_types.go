@@ -166,6 +166,9 @@ type DRPlacementControlStatus struct {
 	// LastUpdateTime is when was the last time a condition or the overall status was updated
 	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
 
+	// ActionGeneration is the generation of the DRPC when the current action started
+	ActionGeneration int64 `json:"actionGeneration,omitempty"`
+
 	// lastGroupSyncTime is the time of the most recent successful synchronization of all PVCs
 	//+optional
 	LastGroupSyncTime *metav1.Time `json:"lastGroupSyncTime,omitempty"`
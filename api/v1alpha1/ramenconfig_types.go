@@ -6,6 +6,7 @@ package v1alpha1
 import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	cfg "sigs.k8s.io/controller-runtime/pkg/config/v1alpha1"
 )
 
@@ -24,6 +25,10 @@ const (
 	DRHubType ControllerType = "dr-hub"
 )
 
+// RamenConfigSchemaVersion is the version of the RamenConfig schema, stamped on the RamenConfig config maps the hub
+// ships to the dr-clusters. It is incremented on changes to RamenConfig that an older operator cannot parse.
+const RamenConfigSchemaVersion = "1"
+
 // When naming a S3 bucket, follow the bucket naming rules at:
 // https://docs.aws.amazon.com/AmazonS3/latest/userguide/bucketnamingrules.html
 // - Bucket names must be between 3 and 63 characters long.
@@ -103,6 +108,11 @@ type RamenConfig struct {
 		// dr-cluster operator deployment/undeployment automation enabled
 		DeploymentAutomationEnabled bool `json:"deploymentAutomationEnabled,omitempty"`
 
+		// Ship only the namespace, configuration and RBAC of the dr-cluster operator, without its OLM Subscription
+		// and OperatorGroup, to every DR cluster, e.g. to disconnected clusters without OLM catalog sources. The
+		// dr-cluster operator must be installed by other means. Requires deploymentAutomationEnabled.
+		OLMDeploymentDisabled bool `json:"olmDeploymentDisabled,omitempty"`
+
 		// Enable s3 secret distribution and management across dr-clusters
 		S3SecretDistributionEnabled bool `json:"s3SecretDistributionEnabled,omitempty"`
 
@@ -123,8 +133,24 @@ type RamenConfig struct {
 
 		// cluster service version name
 		ClusterServiceVersionName string `json:"clusterServiceVersionName,omitempty"`
+
+		// config map name, defaults to ramen-dr-cluster-operator-config
+		ConfigMapName string `json:"configMapName,omitempty"`
+
+		// config map namespace name, defaults to, and if set must match, the dr-cluster operator namespace name
+		ConfigMapNamespaceName string `json:"configMapNamespaceName,omitempty"`
 	} `json:"drClusterOperator,omitempty"`
 
+	// Additional resources, such as a PriorityClass or a NetworkPolicy, to ship to every DR cluster alongside the
+	// resources Ramen requires there. Each is a complete object, with apiVersion, kind and name, and a namespace
+	// unless cluster scoped.
+	DrClusterManifests []runtime.RawExtension `json:"drClusterManifests,omitempty"`
+
+	// Values, keyed by DR cluster name, substituted for ${NAME} placeholders in the string fields of the
+	// configuration shipped to that DR cluster, such as a region specific S3 endpoint. CLUSTER_NAME is the DR
+	// cluster name unless set here.
+	DrClusterConfigValues map[string]map[string]string `json:"drClusterConfigValues,omitempty"`
+
 	// VolSync configuration
 	VolSync struct {
 		// Disabled is used to disable VolSync usage in Ramen. Defaults to false.
@@ -155,6 +181,10 @@ type RamenConfig struct {
 
 	// Unprotect deleted or deselected PVCs
 	VolumeUnprotectionEnabled bool `json:"volumeUnprotectionEnabled,omitempty"`
+
+	// Maximum number of clusters whose ManifestWorks the hub updates concurrently when applying a change to all the
+	// clusters of a DRPolicy, such as demoting the VRGs for a relocation. Defaults to 10.
+	ManifestWorkMaxConcurrentApplies int `json:"manifestWorkMaxConcurrentApplies,omitempty"`
 }
 
 func init() {
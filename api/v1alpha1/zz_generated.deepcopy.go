@@ -753,6 +753,30 @@ func (in *RamenConfig) DeepCopyInto(out *RamenConfig) {
 		}
 	}
 	out.DrClusterOperator = in.DrClusterOperator
+	if in.DrClusterManifests != nil {
+		in, out := &in.DrClusterManifests, &out.DrClusterManifests
+		*out = make([]runtime.RawExtension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DrClusterConfigValues != nil {
+		in, out := &in.DrClusterConfigValues, &out.DrClusterConfigValues
+		*out = make(map[string]map[string]string, len(*in))
+		for key, val := range *in {
+			var outVal map[string]string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+			(*out)[key] = outVal
+		}
+	}
 	out.VolSync = in.VolSync
 	out.KubeObjectProtection = in.KubeObjectProtection
 	out.MultiNamespace = in.MultiNamespace
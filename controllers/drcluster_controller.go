@@ -72,7 +72,12 @@ const (
 )
 
 const (
-	DRClusterNameAnnotation = "drcluster.ramendr.openshift.io/drcluster-name"
+	DRClusterNameAnnotation = util.DRClusterNameAnnotation
+
+	// DRClusterOLMDeploymentAnnotation on a DRCluster, set to DRClusterOLMDeploymentDisabled, skips deploying the
+	// dr-cluster operator OLM Subscription and OperatorGroup to the cluster
+	DRClusterOLMDeploymentAnnotation = "drcluster.ramendr.openshift.io/olm-deployment"
+	DRClusterOLMDeploymentDisabled   = "disabled"
 )
 
 // SetupWithManager sets up the controller with the Manager.
@@ -285,6 +290,7 @@ func (r *DRClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		Log:             log,
 		InstName:        drcluster.Name,
 		TargetNamespace: "",
+		Finalizing:      !drcluster.GetDeletionTimestamp().IsZero(),
 	}
 
 	u := &drclusterInstance{
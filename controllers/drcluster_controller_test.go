@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/gomega/gstruct"
 	gomegaTypes "github.com/onsi/gomega/types"
 	workv1 "github.com/open-cluster-management/api/work/v1"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	ramen "github.com/ramendr/ramen/api/v1alpha1"
 	"github.com/ramendr/ramen/controllers"
 	"github.com/ramendr/ramen/controllers/util"
@@ -62,6 +63,26 @@ func (f FakeMCVGetter) DeleteNFManagedClusterView(
 	return nil
 }
 
+const fakeDrClusterOperatorCSVName = "ramen-dr-cluster-operator.v0.0.1"
+
+func (f FakeMCVGetter) GetSubscriptionFromManagedCluster(
+	resourceName, resourceNamespace, managedCluster string, annotations map[string]string,
+) (*operatorsv1alpha1.Subscription, error) {
+	return &operatorsv1alpha1.Subscription{
+		ObjectMeta: metav1.ObjectMeta{Name: resourceName, Namespace: resourceNamespace},
+		Status:     operatorsv1alpha1.SubscriptionStatus{InstalledCSV: fakeDrClusterOperatorCSVName},
+	}, nil
+}
+
+func (f FakeMCVGetter) GetInstalledCSVFromManagedCluster(
+	subscription *operatorsv1alpha1.Subscription, managedCluster string, annotations map[string]string,
+) (*operatorsv1alpha1.ClusterServiceVersion, error) {
+	return &operatorsv1alpha1.ClusterServiceVersion{
+		ObjectMeta: metav1.ObjectMeta{Name: subscription.Status.InstalledCSV, Namespace: subscription.GetNamespace()},
+		Status:     operatorsv1alpha1.ClusterServiceVersionStatus{Phase: operatorsv1alpha1.CSVPhaseSucceeded},
+	}, nil
+}
+
 func drclusterConditionExpectEventually(
 	drcluster *ramen.DRCluster,
 	disabled bool,
@@ -678,6 +699,26 @@ var _ = Describe("DRClusterController", func() {
 				inspectClusterManifestSubscriptionCSV(true, "fake.v0.0.2", drcluster)
 			})
 		})
+		When("configuration automation is ON and OLM deployment is disabled", func() {
+			It("does NOT create Subscription related manifests", func() {
+				By("updating ramen config to NOT deploy the OLM Subscription and OperatorGroup")
+				ramenConfig.DrClusterOperator.OLMDeploymentDisabled = true
+				configMapUpdate()
+				Eventually(func() bool {
+					sub, err := controllers.SubscriptionFromDrClusterManifestWork(&util.MWUtil{
+						Client:    k8sClient,
+						APIReader: apiReader,
+						Ctx:       context.TODO(),
+						Log:       ctrl.Log.WithName("MWUtilTest"),
+					}, drcluster.Name)
+
+					return err == nil && sub == nil
+				}, timeout, interval).Should(BeTrue())
+				drclusterConditionExpectConsistently(drcluster, false, Equal("Succeeded"), Ignore())
+				ramenConfig.DrClusterOperator.OLMDeploymentDisabled = false
+				configMapUpdate()
+			})
+		})
 		When("deleting a DRCluster with all valid values", func() {
 			It("is successful", func() {
 				drclusterDelete(drcluster)
@@ -707,3 +748,10 @@ var _ = Describe("DRClusterController", func() {
 
 	// TODO s3Secret missing/failing/deleted/recreated
 })
+
+var _ = Describe("VerifyDrClusterOperatorInstalled", func() {
+	It("returns true once the installed ClusterServiceVersion succeeded", func() {
+		Expect(controllers.VerifyDrClusterOperatorInstalled(FakeMCVGetter{}, ramenConfig, "drc-cluster-csv")).
+			To(BeTrue())
+	})
+})
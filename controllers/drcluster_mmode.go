@@ -225,7 +225,7 @@ func (u *drclusterInstance) pruneMModesActivations(
 // TODO: Add the expiration/linger feature, for corner case races between DRPC chcking DRCluster status, while
 // DRCluster is in the process of deleting the maintenance mode
 func (u *drclusterInstance) expireClusterMModeActivation(mw *ocmworkv1.ManifestWork) error {
-	return u.mwUtil.DeleteManifestWork(mw.GetName(), mw.GetNamespace())
+	return u.mwUtil.DeleteManifestWork(mw.GetName(), util.ManifestWorkCluster(mw))
 }
 
 // updateMModeActivationStatus updates maintenance mode status for the cluster based on available
@@ -367,8 +367,9 @@ func drClusterMModeCleanup(
 		return err
 	}
 
-	for _, mw := range mModeMWs.Items {
-		if err := mwu.DeleteManifestWork(mw.GetName(), mw.GetNamespace()); err != nil {
+	for i := range mModeMWs.Items {
+		mw := &mModeMWs.Items[i]
+		if err := mwu.DeleteManifestWork(mw.GetName(), util.ManifestWorkCluster(mw)); err != nil {
 			return err
 		}
 	}
@@ -13,6 +13,7 @@ import (
 	rmn "github.com/ramendr/ramen/api/v1alpha1"
 	"github.com/ramendr/ramen/controllers/util"
 	"github.com/ramendr/ramen/controllers/volsync"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -23,21 +24,32 @@ func drClusterDeploy(drClusterInstance *drclusterInstance, ramenConfig *rmn.Rame
 	drcluster := drClusterInstance.object
 	mwu := drClusterInstance.mwUtil
 
+	if err := drClusterOperatorDeploymentConfigCheck(ramenConfig); err != nil {
+		return err
+	}
+
 	objects := []interface{}{}
 
 	if ramenConfig.DrClusterOperator.DeploymentAutomationEnabled {
-		var err error
+		olmDeploymentEnabled := drClusterOLMDeploymentEnabled(ramenConfig, drcluster)
 
-		objects, err = objectsToDeploy(ramenConfig)
+		shippedConfigMap, err := ConfigMapFromDrClusterManifestWork(mwu, drcluster.Name)
 		if err != nil {
 			return err
 		}
 
-		objects, err = appendSubscriptionObject(drcluster, mwu, ramenConfig, objects)
+		objects, err = objectsToDeploy(ramenConfig, drcluster.Name, shippedConfigMap, olmDeploymentEnabled)
 		if err != nil {
 			return err
 		}
 
+		if olmDeploymentEnabled {
+			objects, err = appendSubscriptionObject(drcluster, mwu, ramenConfig, objects)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Deploy volsync to dr cluster
 		err = volsync.DeployVolSyncToCluster(drClusterInstance.ctx, drClusterInstance.client, drcluster.GetName(),
 			drClusterInstance.log)
@@ -50,7 +62,17 @@ func drClusterDeploy(drClusterInstance *drclusterInstance, ramenConfig *rmn.Rame
 
 	annotations["DRClusterName"] = mwu.InstName
 
-	return mwu.CreateOrUpdateDrClusterManifestWork(drcluster.Name, objects, annotations)
+	return mwu.CreateOrUpdateDrClusterManifestWork(drcluster.Name, objects, annotations,
+		ramenConfig.DrClusterManifests...)
+}
+
+// drClusterOLMDeploymentEnabled returns false if the dr-cluster operator OLM Subscription and OperatorGroup are not
+// to be deployed to the DRCluster, e.g. as the operator is installed on it by other means, either for all clusters
+// using the RamenConfig OLMDeploymentDisabled, or for the DRCluster alone using DRClusterOLMDeploymentAnnotation.
+// Either only applies when dr-cluster operator deployment automation is enabled in the RamenConfig.
+func drClusterOLMDeploymentEnabled(ramenConfig *rmn.RamenConfig, drcluster *rmn.DRCluster) bool {
+	return !ramenConfig.DrClusterOperator.OLMDeploymentDisabled &&
+		drcluster.GetAnnotations()[DRClusterOLMDeploymentAnnotation] != DRClusterOLMDeploymentDisabled
 }
 
 func appendSubscriptionObject(
@@ -101,31 +123,66 @@ var olmClusterRole = &rbacv1.ClusterRole{
 	},
 }
 
-func objectsToDeploy(hubOperatorRamenConfig *rmn.RamenConfig) ([]interface{}, error) {
+// objectsToDeploy returns the objects to deploy the dr-cluster operator. The shippedConfigMap, if not nil, is the
+// dr-cluster operator config map in the existing DRCluster ManifestWork, and is shipped again as is unless
+// NeedsConfigUpgrade, to avoid rolling the dr-cluster operator configuration unnecessarily. The OperatorGroup is
+// included only if olmDeploymentEnabled is set.
+func objectsToDeploy(
+	hubOperatorRamenConfig *rmn.RamenConfig,
+	clusterName string,
+	shippedConfigMap *corev1.ConfigMap,
+	olmDeploymentEnabled bool,
+) ([]interface{}, error) {
 	objects := []interface{}{}
 
 	drClusterOperatorRamenConfig := *hubOperatorRamenConfig
 	ramenConfig := &drClusterOperatorRamenConfig
-	drClusterOperatorNamespaceName := drClusterOperatorNamespaceNameOrDefault(ramenConfig)
 	ramenConfig.LeaderElection.ResourceName = drClusterLeaderElectionResourceName
 	ramenConfig.RamenControllerType = rmn.DRClusterType
+	// Shipped in the DRCluster ManifestWork itself, and not used by the dr-cluster operator
+	ramenConfig.DrClusterManifests = nil
+	// Substituted below, for this cluster only
+	ramenConfig.DrClusterConfigValues = nil
+
+	ramenConfig, err := RamenConfigTemplated(ramenConfig, drClusterConfigValues(hubOperatorRamenConfig, clusterName))
+	if err != nil {
+		return nil, fmt.Errorf("drcluster '%v' config: %w", clusterName, err)
+	}
+
+	drClusterOperatorNamespaceName := drClusterOperatorNamespaceNameOrDefault(ramenConfig)
+
+	drClusterOperatorConfigMapNamespaceName, err := drClusterOperatorConfigMapNamespaceNameOrDefault(ramenConfig)
+	if err != nil {
+		return nil, err
+	}
 
 	drClusterOperatorConfigMap, err := ConfigMapNew(
-		drClusterOperatorNamespaceName,
-		DrClusterOperatorConfigMapName,
+		drClusterOperatorConfigMapNamespaceName,
+		drClusterOperatorConfigMapNameOrDefault(ramenConfig),
 		ramenConfig,
 	)
 	if err != nil {
 		return nil, err
 	}
 
-	return append(objects,
+	if shippedConfigMap != nil &&
+		shippedConfigMap.GetName() == drClusterOperatorConfigMap.GetName() &&
+		shippedConfigMap.GetNamespace() == drClusterOperatorConfigMap.GetNamespace() &&
+		!NeedsConfigUpgrade(shippedConfigMap, ramenConfig) {
+		drClusterOperatorConfigMap = shippedConfigMap
+	}
+
+	objects = append(objects,
 		util.Namespace(drClusterOperatorNamespaceName),
 		olmClusterRole,
 		olmRoleBinding(drClusterOperatorNamespaceName),
-		operatorGroup(drClusterOperatorNamespaceName),
-		drClusterOperatorConfigMap,
-	), nil
+	)
+
+	if olmDeploymentEnabled {
+		objects = append(objects, operatorGroup(drClusterOperatorNamespaceName))
+	}
+
+	return append(objects, drClusterOperatorConfigMap), nil
 }
 
 func olmRoleBinding(namespaceName string) *rbacv1.RoleBinding {
@@ -157,6 +214,20 @@ func operatorGroup(namespaceName string) *operatorsv1.OperatorGroup {
 	}
 }
 
+const drClusterOperatorSubscriptionName = "ramen-dr-cluster-subscription"
+
+// VerifyDrClusterOperatorInstalled returns true once the dr-cluster operator ClusterServiceVersion installed for the
+// Subscription shipped in the DRCluster ManifestWork reaches the Succeeded phase on the cluster, and an error
+// wrapping util.ErrOperatorInstallFailed with the OLM reported message if its installation failed
+func VerifyDrClusterOperatorInstalled(
+	mcv util.ManagedClusterViewGetter,
+	ramenConfig *rmn.RamenConfig,
+	clusterName string,
+) (bool, error) {
+	return util.VerifyOperatorInstalled(mcv, drClusterOperatorSubscriptionName,
+		drClusterOperatorNamespaceNameOrDefault(ramenConfig), clusterName)
+}
+
 func subscription(
 	namespaceName string,
 	channelName string,
@@ -167,7 +238,7 @@ func subscription(
 ) *operatorsv1alpha1.Subscription {
 	return &operatorsv1alpha1.Subscription{
 		TypeMeta:   metav1.TypeMeta{Kind: "Subscription", APIVersion: "operators.coreos.com/v1alpha1"},
-		ObjectMeta: metav1.ObjectMeta{Name: "ramen-dr-cluster-subscription", Namespace: namespaceName},
+		ObjectMeta: metav1.ObjectMeta{Name: drClusterOperatorSubscriptionName, Namespace: namespaceName},
 		Spec: &operatorsv1alpha1.SubscriptionSpec{
 			CatalogSource:          catalogSourceName,
 			CatalogSourceNamespace: catalogSourceNamespaceName,
@@ -217,6 +288,41 @@ func SubscriptionFromDrClusterManifestWork(
 	return subscription, nil
 }
 
+// ConfigMapFromDrClusterManifestWork returns the dr-cluster operator config map in the DRCluster ManifestWork, if any
+func ConfigMapFromDrClusterManifestWork(
+	mwu *util.MWUtil,
+	clusterName string,
+) (*corev1.ConfigMap, error) {
+	mw, err := mwu.GetDrClusterManifestWork(clusterName)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching cluster manifest work %w", err)
+	}
+
+	if mw == nil {
+		return nil, nil
+	}
+
+	gvk := corev1.SchemeGroupVersion.WithKind("ConfigMap")
+
+	configMapRaw, err := util.GetRawExtension(mw.Spec.Workload.Manifests, gvk)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching config map from cluster '%v' manifest %w", clusterName, err)
+	}
+
+	if configMapRaw == nil {
+		return nil, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+
+	err = json.Unmarshal(configMapRaw.Raw, configMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed unmarshaling config map manifest for cluster '%v' %w", clusterName, err)
+	}
+
+	return configMap, nil
+}
+
 func drClusterUndeploy(
 	drcluster *rmn.DRCluster,
 	mwu *util.MWUtil,
@@ -243,6 +349,18 @@ func drClusterUndeploy(
 		return err
 	}
 
+	sub, err := SubscriptionFromDrClusterManifestWork(mwu, drcluster.Name)
+	if err != nil {
+		return fmt.Errorf("drcluster '%v' subscription get: %w", drcluster.Name, err)
+	}
+
+	if sub != nil {
+		if err := util.DeleteSubscriptionManagedClusterViews(mcv, sub.GetName(), sub.GetNamespace(),
+			drcluster.Name); err != nil {
+			return fmt.Errorf("drcluster '%v' subscription views delete: %w", drcluster.Name, err)
+		}
+	}
+
 	if err := mwu.DeleteManifestWork(util.DrClusterManifestWorkName, drcluster.Name); err != nil {
 		return fmt.Errorf("drcluster '%v' manifest work delete: %w", drcluster.Name, err)
 	}
@@ -28,14 +28,18 @@ import (
 
 const (
 	// Annotations for MW and PlacementRule
-	DRPCNameAnnotation      = "drplacementcontrol.ramendr.openshift.io/drpc-name"
-	DRPCNamespaceAnnotation = "drplacementcontrol.ramendr.openshift.io/drpc-namespace"
+	DRPCNameAnnotation      = rmnutil.DRPCNameAnnotation
+	DRPCNamespaceAnnotation = rmnutil.DRPCNamespaceAnnotation
 
 	// Annotation for the last cluster on which the application was running
 	LastAppDeploymentCluster = "drplacementcontrol.ramendr.openshift.io/last-app-deployment-cluster"
 
 	// Annotation for application namespace on the managed cluster
 	DRPCAppNamespace = "drplacementcontrol.ramendr.openshift.io/app-namespace"
+
+	// Annotation to declare the application namespace on the managed clusters as managed by another tool, e.g.
+	// GitOps, when set to "true", in which case no Namespace ManifestWork is created for it
+	DRPCNamespaceExternallyManagedAnnotation = "drplacementcontrol.ramendr.openshift.io/namespace-externally-managed"
 )
 
 var (
@@ -56,10 +60,12 @@ type DRPCInstance struct {
 	drClusters           []rmn.DRCluster
 	mcvRequestInProgress bool
 	volSyncDisabled      bool
+	s3StoreProfiles      []rmn.S3StoreProfile
 	userPlacement        client.Object
 	vrgs                 map[string]*rmn.VolumeReplicationGroup
 	vrgNamespace         string
 	mwu                  rmnutil.MWUtil
+	requeueAfter         time.Duration
 }
 
 func (d *DRPCInstance) startProcessing() bool {
@@ -84,6 +90,8 @@ func (d *DRPCInstance) startProcessing() bool {
 	if processingErr != nil {
 		d.log.Info("Process placement", "error", processingErr.Error())
 
+		d.requeueAfter = rmnutil.ManifestWorkRequeueAfter(processingErr)
+
 		return requeue
 	}
 
@@ -1312,24 +1320,20 @@ func (d *DRPCInstance) mwExistsAndPlacementUpdated(targetCluster string) (bool,
 func (d *DRPCInstance) moveVRGToSecondaryEverywhere() bool {
 	d.log.Info("Move VRG to secondary everywhere")
 
-	failedCount := 0
-
-	for _, clusterName := range rmnutil.DrpolicyClusterNames(d.drPolicy) {
+	// VRGs are demoted on all clusters concurrently, up to the MWUtil MaxConcurrentApplies at a time
+	err := d.mwu.ApplySpreader().SpreadApply(rmnutil.DrpolicyClusterNames(d.drPolicy), func(clusterName string) error {
 		_, err := d.updateVRGState(clusterName, rmn.Secondary)
-		if err != nil {
-			if errors.IsNotFound(err) {
-				continue
-			}
-
+		if err != nil && !errors.IsNotFound(err) {
 			d.log.Info(fmt.Sprintf("Failed to update VRG to secondary on cluster %s. Error %s",
 				clusterName, err.Error()))
 
-			failedCount++
+			return err
 		}
-	}
 
-	if failedCount != 0 {
-		d.log.Info("Failed to update VRG to secondary", "FailedCount", failedCount)
+		return nil
+	})
+	if err != nil {
+		d.log.Info("Failed to update VRG to secondary", "error", err.Error())
 
 		return false
 	}
@@ -1432,21 +1436,23 @@ func (d *DRPCInstance) createVRGManifestWork(homeCluster string, repState rmn.Re
 		"Last State:", d.getLastDRState(), "cluster", homeCluster)
 
 	vrg := d.generateVRG(repState)
-	vrg.Spec.VolSync.Disabled = d.volSyncDisabled
 
 	annotations := make(map[string]string)
 
 	annotations[DRPCNameAnnotation] = d.instance.Name
 	annotations[DRPCNamespaceAnnotation] = d.instance.Namespace
 
-	if err := d.mwu.CreateOrUpdateVRGManifestWork(
+	mwState, err := d.mwu.EnsureVRGManifestWork(
 		d.instance.Name, d.vrgNamespace,
-		homeCluster, vrg, annotations); err != nil {
+		homeCluster, vrg, annotations, false, d.vrgOptions()...)
+	if err != nil {
 		d.log.Error(err, "failed to create or update VolumeReplicationGroup manifest")
 
 		return fmt.Errorf("failed to create or update VolumeReplicationGroup manifest in namespace %s (%w)", homeCluster, err)
 	}
 
+	d.log.Info("VRG ManifestWork ensured", "cluster", homeCluster, "state", mwState)
+
 	return nil
 }
 
@@ -1498,18 +1504,27 @@ func (d *DRPCInstance) generateVRG(repState rmn.ReplicationState) rmn.VolumeRepl
 		Spec: rmn.VolumeReplicationGroupSpec{
 			PVCSelector:          d.instance.Spec.PVCSelector,
 			ReplicationState:     repState,
-			S3Profiles:           rmnutil.DRPolicyS3Profiles(d.drPolicy, d.drClusters).List(),
 			KubeObjectProtection: d.instance.Spec.KubeObjectProtection,
 		},
 	}
 
 	d.setVRGAction(&vrg)
-	vrg.Spec.Async = d.generateVRGSpecAsync()
-	vrg.Spec.Sync = d.generateVRGSpecSync()
 
 	return vrg
 }
 
+// vrgOptions returns the S3 profile and replication mode specific VRG options as per the DRPolicy and ramen
+// configuration, and propagates the user annotations of the DRPC to the VRG
+func (d *DRPCInstance) vrgOptions() []rmnutil.VRGOption {
+	return []rmnutil.VRGOption{
+		rmnutil.WithVRGPropagatedAnnotations(d.instance.GetAnnotations()),
+		rmnutil.WithVRGS3Profiles(rmnutil.DRPolicyS3Profiles(d.drPolicy, d.drClusters).List(), d.s3StoreProfiles),
+		rmnutil.WithVRGAsync(d.generateVRGSpecAsync()),
+		rmnutil.WithVRGSync(d.generateVRGSpecSync()),
+		rmnutil.WithVRGVolSyncDisabled(d.volSyncDisabled),
+	}
+}
+
 func (d *DRPCInstance) generateVRGSpecAsync() *rmn.VRGAsyncSpec {
 	if dRPolicySupportsRegional(d.drPolicy, d.drClusters) {
 		return &rmn.VRGAsyncSpec{
@@ -1592,6 +1607,13 @@ func drClusterRegion(drClusters []rmn.DRCluster, cluster string) (region rmn.Reg
 }
 
 func (d *DRPCInstance) ensureNamespaceExistsOnManagedCluster(homeCluster string) error {
+	if d.namespaceExternallyManaged() {
+		d.log.Info(fmt.Sprintf("ensureNamespaceExistsOnManagedCluster: namespace '%s' is externally managed",
+			d.vrgNamespace))
+
+		return nil
+	}
+
 	// verify namespace exists on target cluster
 	namespaceExists, err := d.namespaceExistsOnManagedCluster(homeCluster)
 
@@ -1604,7 +1626,8 @@ func (d *DRPCInstance) ensureNamespaceExistsOnManagedCluster(homeCluster string)
 		annotations[DRPCNameAnnotation] = d.instance.Name
 		annotations[DRPCNamespaceAnnotation] = d.instance.Namespace
 
-		err := d.mwu.CreateOrUpdateNamespaceManifest(d.instance.Name, d.vrgNamespace, homeCluster, annotations)
+		err := d.mwu.CreateOrUpdateNamespaceManifest(d.instance.Name, d.vrgNamespace, homeCluster, annotations,
+			nil, nil)
 		if err != nil {
 			return fmt.Errorf("failed to create namespace '%s' on cluster %s: %w", d.vrgNamespace, homeCluster, err)
 		}
@@ -1623,6 +1646,12 @@ func (d *DRPCInstance) ensureNamespaceExistsOnManagedCluster(homeCluster string)
 	return nil
 }
 
+// namespaceExternallyManaged returns true if the DRPC declares, using DRPCNamespaceExternallyManagedAnnotation, that
+// the application namespace is created on the managed clusters by other means
+func (d *DRPCInstance) namespaceExternallyManaged() bool {
+	return d.instance.GetAnnotations()[DRPCNamespaceExternallyManagedAnnotation] == "true"
+}
+
 func isVRGPrimary(vrg *rmn.VolumeReplicationGroup) bool {
 	return (vrg.Spec.ReplicationState == rmn.Primary)
 }
@@ -2112,6 +2141,9 @@ func (d *DRPCInstance) updateManifestWork(clusterName string, vrg *rmn.VolumeRep
 
 	mw.Spec.Workload.Manifests[0] = *vrgClientManifest
 
+	// The VRG no longer is as generated for the source generation stamped, if any
+	delete(mw.Annotations, rmnutil.SourceGenerationAnnotation)
+
 	return d.reconciler.Update(d.ctx, mw)
 }
 
@@ -2436,6 +2468,8 @@ func (d *DRPCInstance) setStatusInitiating() {
 
 	d.instance.Status.ActionStartTime = &metav1.Time{Time: time.Now()}
 	d.instance.Status.ActionDuration = nil
+	d.instance.Status.ActionGeneration = d.instance.Generation
+	d.mwu.OperationID = drpcOperationID(d.instance)
 }
 
 func (d *DRPCInstance) setActionDuration() {
@@ -5,8 +5,11 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -15,6 +18,7 @@ import (
 	errorswrapper "github.com/pkg/errors"
 	viewv1beta1 "github.com/stolostron/multicloud-operators-foundation/pkg/apis/view/v1beta1"
 	plrv1 "github.com/stolostron/multicloud-operators-placementrule/pkg/apis/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -57,6 +61,10 @@ const (
 	// Maximum retries to create PlacementDecisionName with an increasing index in case of conflicts
 	// with existing PlacementDecision resources
 	MaxPlacementDecisionConflictCount = 5
+
+	// vrgSourceGenerationVersion is part of the VRG ManifestWork source generation, to be changed whenever the VRG
+	// generated from the same DRPC, DRPolicy, DRClusters and configuration changes
+	vrgSourceGenerationVersion = "1"
 )
 
 var InitialWaitTimeForDRPCPlacementRule = errorswrapper.New("Waiting for DRPC Placement to produces placement decision")
@@ -576,6 +584,7 @@ func (r *DRPlacementControlReconciler) SetupWithManager(mgr ctrl.Manager) error
 // +kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=placements/finalizers,verbs=update
 // +kubebuilder:rbac:groups=argoproj.io,resources=applicationsets,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -780,7 +789,7 @@ func (r *DRPlacementControlReconciler) createDRPCInstance(
 		return nil, err
 	}
 
-	_, ramenConfig, err := ConfigMapGet(ctx, r.APIReader)
+	configMap, ramenConfig, err := ConfigMapGet(ctx, r.APIReader)
 	if err != nil {
 		return nil, fmt.Errorf("configmap get: %w", err)
 	}
@@ -796,13 +805,19 @@ func (r *DRPlacementControlReconciler) createDRPCInstance(
 		vrgs:            vrgs,
 		vrgNamespace:    vrgNamespace,
 		volSyncDisabled: ramenConfig.VolSync.Disabled,
+		s3StoreProfiles: ramenConfig.S3StoreProfiles,
 		mwu: rmnutil.MWUtil{
-			Client:          r.Client,
-			APIReader:       r.APIReader,
-			Ctx:             ctx,
-			Log:             log,
-			InstName:        drpc.Name,
-			TargetNamespace: vrgNamespace,
+			Client:               r.Client,
+			APIReader:            r.APIReader,
+			Ctx:                  ctx,
+			Log:                  log,
+			InstName:             drpc.Name,
+			TargetNamespace:      vrgNamespace,
+			EventRecorder:        r.eventRecorder,
+			EventObject:          drpc,
+			MaxConcurrentApplies: ramenConfig.ManifestWorkMaxConcurrentApplies,
+			OperationID:          drpcOperationID(drpc),
+			SourceGeneration:     vrgSourceGeneration(drpc, drPolicy, drClusters, configMap, vrgNamespace),
 		},
 	}
 
@@ -819,6 +834,52 @@ func (r *DRPlacementControlReconciler) createDRPCInstance(
 	return d, nil
 }
 
+// drpcOperationID returns the OperationID of the ManifestWorks created or updated for the current action of drpc. It
+// is derived from the action and the DRPC generation when the action started, rather than generated, so that it is
+// the same across the reconciles of the action.
+func drpcOperationID(drpc *rmn.DRPlacementControl) string {
+	action := string(drpc.Spec.Action)
+	if action == "" {
+		action = "Deploy"
+	}
+
+	return fmt.Sprintf("%s-%s-%d", drpc.UID, action, drpc.Status.ActionGeneration)
+}
+
+// vrgSourceGeneration returns the MWUtil SourceGeneration for the VRG ManifestWorks of drpc. It changes with any of
+// the inputs the VRG is generated from: the DRPC spec and annotations, the DRPolicy and DRClusters specs, the ramen
+// configuration and the VRG namespace.
+func vrgSourceGeneration(drpc *rmn.DRPlacementControl, drPolicy *rmn.DRPolicy, drClusters []rmn.DRCluster,
+	configMap *corev1.ConfigMap, vrgNamespace string,
+) string {
+	inputs := []string{
+		"version " + vrgSourceGenerationVersion,
+		fmt.Sprintf("drpc %s %d", drpc.UID, drpc.Generation),
+		fmt.Sprintf("drpolicy %s %d", drPolicy.UID, drPolicy.Generation),
+		fmt.Sprintf("config %s %s", configMap.UID, configMap.ResourceVersion),
+		"namespace " + vrgNamespace,
+	}
+
+	for i := range drClusters {
+		inputs = append(inputs, fmt.Sprintf("drcluster %s %d", drClusters[i].UID, drClusters[i].Generation))
+	}
+
+	for key, value := range drpc.GetAnnotations() {
+		inputs = append(inputs, fmt.Sprintf("annotation %s=%s", key, value))
+	}
+
+	sort.Strings(inputs)
+
+	hash := sha256.New()
+
+	for _, input := range inputs {
+		hash.Write([]byte(input))
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
 func (r *DRPlacementControlReconciler) createDRPCMetricsInstance(
 	drPolicy *rmn.DRPolicy, drpc *rmn.DRPlacementControl,
 ) *DRPCMetrics {
@@ -866,6 +927,12 @@ func (r *DRPlacementControlReconciler) reconcileDRPCInstance(d *DRPCInstance, lo
 		return reconcile.Result{RequeueAfter: duration}, nil
 	}
 
+	if requeue && d.requeueAfter > 0 {
+		log.Info(fmt.Sprintf("Requeing after %v as suggested for the ManifestWork state", d.requeueAfter))
+
+		return ctrl.Result{RequeueAfter: d.requeueAfter}, nil
+	}
+
 	if requeue {
 		log.Info("Requeing...")
 
@@ -1049,6 +1116,9 @@ func (r *DRPlacementControlReconciler) finalizeDRPC(ctx context.Context, drpc *r
 		Log:             r.Log,
 		InstName:        drpc.Name,
 		TargetNamespace: vrgNamespace,
+		EventRecorder:   r.eventRecorder,
+		EventObject:     drpc,
+		Finalizing:      true,
 	}
 
 	drPolicy, err := r.getDRPolicy(ctx, drpc, log)
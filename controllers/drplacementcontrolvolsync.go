@@ -273,6 +273,9 @@ func (d *DRPCInstance) updateVRGSpec(clusterName string, tgtVRG *rmn.VolumeRepli
 
 	mw.Spec.Workload.Manifests[0] = *vrgClientManifest
 
+	// The VRG no longer is as generated for the source generation stamped, if any
+	delete(mw.Annotations, rmnutil.SourceGenerationAnnotation)
+
 	err = d.reconciler.Update(d.ctx, mw)
 	if err != nil {
 		return fmt.Errorf("failed to update MW (%w)", err)
@@ -311,7 +314,7 @@ func (d *DRPCInstance) createVolSyncDestManifestWork(clusterToSkip string) error
 		vrg := d.generateVRG(rmn.Secondary)
 		if err := d.mwu.CreateOrUpdateVRGManifestWork(
 			d.instance.Name, d.vrgNamespace,
-			dstCluster, vrg, annotations); err != nil {
+			dstCluster, vrg, annotations, false, d.vrgOptions()...); err != nil {
 			d.log.Error(err, "failed to create or update VolumeReplicationGroup manifest")
 
 			return fmt.Errorf("failed to create or update VolumeReplicationGroup manifest in namespace %s (%w)", dstCluster, err)
@@ -375,6 +378,9 @@ func (d *DRPCInstance) ResetVolSyncRDOnPrimary(clusterName string) error {
 
 	mw.Spec.Workload.Manifests[0] = *vrgClientManifest
 
+	// The VRG no longer is as generated for the source generation stamped, if any
+	delete(mw.Annotations, rmnutil.SourceGenerationAnnotation)
+
 	err = d.reconciler.Update(d.ctx, mw)
 	if err != nil {
 		return fmt.Errorf("failed to update MW (%w)", err)
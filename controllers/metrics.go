@@ -5,7 +5,9 @@ package controllers
 
 import (
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
@@ -195,6 +197,19 @@ func DeleteSyncDataBytesMetric(labels prometheus.Labels) bool {
 	return lastSyncDataBytes.Delete(labels)
 }
 
+// DRPCGaugeMetricValue returns the value of the named ramen gauge vector metric, such as LastSyncTimestampSeconds,
+// reported for the DRPC
+func DRPCGaugeMetricValue(name string, drpc *rmn.DRPlacementControl) (float64, error) {
+	return rmnutil.GetMetricValueWithLabels(
+		prometheus.BuildFQName(metricNamespace, "", name),
+		dto.MetricType_GAUGE,
+		map[string]string{
+			ObjName:      drpc.Name,
+			ObjNamespace: drpc.Namespace,
+		},
+	)
+}
+
 func init() {
 	// Register custom metrics with the global prometheus registry
 	metrics.Registry.MustRegister(dRPolicySyncInterval)
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers"
+)
+
+var _ = Describe("DRPCGaugeMetricValue", func() {
+	drpolicy := &rmn.DRPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "metrics-drpolicy"},
+		Spec:       rmn.DRPolicySpec{SchedulingInterval: "5m"},
+	}
+
+	newDRPC := func(name string) *rmn.DRPlacementControl {
+		return &rmn.DRPlacementControl{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "metrics-ns"}}
+	}
+
+	It("reads the sync gauge reported for each DRPC", func() {
+		drpc1 := newDRPC("metrics-drpc1")
+		drpc2 := newDRPC("metrics-drpc2")
+
+		controllers.NewSyncMetrics(controllers.SyncMetricLabels(drpolicy, drpc1)).LastSyncTime.Set(100)
+		controllers.NewSyncMetrics(controllers.SyncMetricLabels(drpolicy, drpc2)).LastSyncTime.Set(200)
+
+		defer func() {
+			controllers.DeleteSyncMetric(controllers.SyncMetricLabels(drpolicy, drpc1))
+			controllers.DeleteSyncMetric(controllers.SyncMetricLabels(drpolicy, drpc2))
+		}()
+
+		Expect(controllers.DRPCGaugeMetricValue(controllers.LastSyncTimestampSeconds, drpc1)).To(Equal(100.0))
+		Expect(controllers.DRPCGaugeMetricValue(controllers.LastSyncTimestampSeconds, drpc2)).To(Equal(200.0))
+
+		_, err := controllers.DRPCGaugeMetricValue(controllers.LastSyncTimestampSeconds, newDRPC("metrics-drpc3"))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -4,17 +4,22 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/url"
 	"os"
+	"regexp"
+	"strings"
 
 	"github.com/go-logr/logr"
 	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
@@ -41,8 +46,18 @@ const (
 	DefaultCephFSCSIDriverName                        = "openshift-storage.cephfs.csi.ceph.com"
 	VeleroNamespaceNameDefault                        = "velero"
 	DefaultVolSyncCopyMethod                          = "Snapshot"
+
+	// ConfigMapSchemaVersionAnnotation on RamenConfig config maps records the RamenConfigSchemaVersion of the
+	// embedded RamenConfig
+	ConfigMapSchemaVersionAnnotation = "ramendr.openshift.io/config-schema-version"
+
+	// RamenConfigClusterNameValue is the placeholder name for the DR cluster name in a templated RamenConfig
+	RamenConfigClusterNameValue = "CLUSTER_NAME"
 )
 
+// ramenConfigPlaceholder matches a ${NAME} placeholder in a RamenConfig string value
+var ramenConfigPlaceholder = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
 var (
 	VolumeUnprotectionEnabledForAsyncVolRep  = false
 	VolumeUnprotectionEnabledForAsyncVolSync = false
@@ -197,11 +212,18 @@ func ConfigMapNew(
 		return nil, fmt.Errorf("config map yaml marshal %w", err)
 	}
 
+	if err := ramenConfigYamlRoundTripCheck(ramenConfigYaml); err != nil {
+		return nil, err
+	}
+
 	return &corev1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespaceName,
+			Annotations: map[string]string{
+				ConfigMapSchemaVersionAnnotation: ramendrv1alpha1.RamenConfigSchemaVersion,
+			},
 		},
 		Data: map[string]string{
 			ConfigMapRamenConfigKeyName: string(ramenConfigYaml),
@@ -209,6 +231,27 @@ func ConfigMapNew(
 	}, nil
 }
 
+// ramenConfigYamlRoundTripCheck returns an error if the marshaled RamenConfig does not unmarshal back into a
+// RamenConfig that marshals to the same YAML, which would indicate a serialization regression losing configuration
+func ramenConfigYamlRoundTripCheck(ramenConfigYaml []byte) error {
+	ramenConfig := &ramendrv1alpha1.RamenConfig{}
+	if err := yaml.Unmarshal(ramenConfigYaml, ramenConfig); err != nil {
+		return fmt.Errorf("config map yaml round trip unmarshal %w", err)
+	}
+
+	roundTrippedYaml, err := yaml.Marshal(ramenConfig)
+	if err != nil {
+		return fmt.Errorf("config map yaml round trip marshal %w", err)
+	}
+
+	if !bytes.Equal(ramenConfigYaml, roundTrippedYaml) {
+		return fmt.Errorf("config map yaml round trip mismatch, marshaled:\n%s\nround tripped:\n%s",
+			ramenConfigYaml, roundTrippedYaml)
+	}
+
+	return nil
+}
+
 func ConfigMapGet(
 	ctx context.Context,
 	apiReader client.Reader,
@@ -236,6 +279,144 @@ func ConfigMapGet(
 	return
 }
 
+// ParseDrClusterConfigMap returns the RamenConfig embedded in a ConfigMap generated by ConfigMapNew
+func ParseDrClusterConfigMap(configMap *corev1.ConfigMap) (*ramendrv1alpha1.RamenConfig, error) {
+	ramenConfigYaml, ok := configMap.Data[ConfigMapRamenConfigKeyName]
+	if !ok {
+		return nil, fmt.Errorf("config map %s/%s missing key %s",
+			configMap.GetNamespace(), configMap.GetName(), ConfigMapRamenConfigKeyName)
+	}
+
+	ramenConfig := &ramendrv1alpha1.RamenConfig{}
+	if err := yaml.Unmarshal([]byte(ramenConfigYaml), ramenConfig); err != nil {
+		return nil, fmt.Errorf("config map yaml unmarshal %w", err)
+	}
+
+	return ramenConfig, nil
+}
+
+// RamenConfigEqual returns true if the passed in RamenConfigs are the same, other than in the leader election
+// resource name and controller type that differ between the hub and dr-cluster operator configurations
+func RamenConfigEqual(a, b *ramendrv1alpha1.RamenConfig) bool {
+	aYaml, err := yaml.Marshal(ramenConfigNormalized(a))
+	if err != nil {
+		return false
+	}
+
+	bYaml, err := yaml.Marshal(ramenConfigNormalized(b))
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(aYaml, bYaml)
+}
+
+// NeedsConfigUpgrade returns true if the dr-cluster operator config map applied to a cluster must be rolled to
+// desiredConfig, as it is missing, was generated for a different RamenConfigSchemaVersion, or embeds a RamenConfig
+// that differs from desiredConfig
+func NeedsConfigUpgrade(appliedConfigMap *corev1.ConfigMap, desiredConfig *ramendrv1alpha1.RamenConfig) bool {
+	if appliedConfigMap == nil ||
+		appliedConfigMap.GetAnnotations()[ConfigMapSchemaVersionAnnotation] != ramendrv1alpha1.RamenConfigSchemaVersion {
+		return true
+	}
+
+	appliedConfig, err := ParseDrClusterConfigMap(appliedConfigMap)
+	if err != nil {
+		return true
+	}
+
+	return !RamenConfigEqual(appliedConfig, desiredConfig)
+}
+
+// RamenConfigTemplated returns a copy of ramenConfig with each ${NAME} placeholder in its string values replaced by
+// values[NAME]. An error naming the placeholders missing from values is returned if any are.
+func RamenConfigTemplated(
+	ramenConfig *ramendrv1alpha1.RamenConfig,
+	values map[string]string,
+) (*ramendrv1alpha1.RamenConfig, error) {
+	ramenConfigJSON, err := json.Marshal(ramenConfig)
+	if err != nil {
+		return nil, fmt.Errorf("config template json marshal %w", err)
+	}
+
+	var object interface{}
+
+	decoder := json.NewDecoder(bytes.NewReader(ramenConfigJSON))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&object); err != nil {
+		return nil, fmt.Errorf("config template json unmarshal %w", err)
+	}
+
+	missing := sets.NewString()
+	object = ramenConfigSubstitute(object, values, missing)
+
+	if missing.Len() > 0 {
+		return nil, fmt.Errorf("config template values missing for %s", strings.Join(missing.List(), ", "))
+	}
+
+	if ramenConfigJSON, err = json.Marshal(object); err != nil {
+		return nil, fmt.Errorf("config templated json marshal %w", err)
+	}
+
+	templated := &ramendrv1alpha1.RamenConfig{}
+	if err := json.Unmarshal(ramenConfigJSON, templated); err != nil {
+		return nil, fmt.Errorf("config templated json unmarshal %w", err)
+	}
+
+	return templated, nil
+}
+
+func ramenConfigSubstitute(object interface{}, values map[string]string, missing sets.String) interface{} {
+	switch value := object.(type) {
+	case string:
+		return ramenConfigPlaceholder.ReplaceAllStringFunc(value, func(placeholder string) string {
+			name := ramenConfigPlaceholder.FindStringSubmatch(placeholder)[1]
+
+			substitute, ok := values[name]
+			if !ok {
+				missing.Insert(name)
+
+				return placeholder
+			}
+
+			return substitute
+		})
+	case map[string]interface{}:
+		for key := range value {
+			value[key] = ramenConfigSubstitute(value[key], values, missing)
+		}
+	case []interface{}:
+		for i := range value {
+			value[i] = ramenConfigSubstitute(value[i], values, missing)
+		}
+	}
+
+	return object
+}
+
+// drClusterConfigValues returns the values to template the configuration shipped to the named DR cluster with
+func drClusterConfigValues(ramenConfig *ramendrv1alpha1.RamenConfig, clusterName string) map[string]string {
+	values := map[string]string{RamenConfigClusterNameValue: clusterName}
+
+	for name, value := range ramenConfig.DrClusterConfigValues[clusterName] {
+		values[name] = value
+	}
+
+	return values
+}
+
+func ramenConfigNormalized(ramenConfig *ramendrv1alpha1.RamenConfig) *ramendrv1alpha1.RamenConfig {
+	normalized := ramenConfig.DeepCopy()
+	normalized.RamenControllerType = ""
+
+	if normalized.LeaderElection != nil {
+		normalized.LeaderElection.ResourceName = ""
+	}
+
+	return normalized
+}
+
 func NamespaceName() string {
 	return os.Getenv("POD_NAMESPACE")
 }
@@ -292,6 +473,35 @@ func drClusterOperatorClusterServiceVersionNameOrDefault(ramenConfig *ramendrv1a
 	return ramenConfig.DrClusterOperator.ClusterServiceVersionName
 }
 
+func drClusterOperatorConfigMapNameOrDefault(ramenConfig *ramendrv1alpha1.RamenConfig) string {
+	if ramenConfig.DrClusterOperator.ConfigMapName == "" {
+		return DrClusterOperatorConfigMapName
+	}
+
+	return ramenConfig.DrClusterOperator.ConfigMapName
+}
+
+func drClusterOperatorConfigMapNamespaceNameOrDefault(ramenConfig *ramendrv1alpha1.RamenConfig) (string, error) {
+	namespaceName := drClusterOperatorNamespaceNameOrDefault(ramenConfig)
+
+	if ramenConfig.DrClusterOperator.ConfigMapNamespaceName != "" &&
+		ramenConfig.DrClusterOperator.ConfigMapNamespaceName != namespaceName {
+		return "", fmt.Errorf("dr-cluster operator config map namespace %s does not match operator namespace %s",
+			ramenConfig.DrClusterOperator.ConfigMapNamespaceName, namespaceName)
+	}
+
+	return namespaceName, nil
+}
+
+func drClusterOperatorDeploymentConfigCheck(ramenConfig *ramendrv1alpha1.RamenConfig) error {
+	if ramenConfig.DrClusterOperator.OLMDeploymentDisabled &&
+		!ramenConfig.DrClusterOperator.DeploymentAutomationEnabled {
+		return fmt.Errorf("dr-cluster operator olmDeploymentDisabled requires deploymentAutomationEnabled")
+	}
+
+	return nil
+}
+
 func cephFSCSIDriverNameOrDefault(ramenConfig *ramendrv1alpha1.RamenConfig) string {
 	if ramenConfig.VolSync.CephFSCSIDriverName == "" {
 		return DefaultCephFSCSIDriverName
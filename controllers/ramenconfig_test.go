@@ -66,3 +66,136 @@ func s3ProfilesStore(s3Profiles []ramen.S3StoreProfile) {
 
 	configMapUpdate()
 }
+
+var _ = Describe("ParseDrClusterConfigMap", func() {
+	It("returns the RamenConfig the config map was generated from", func() {
+		drClusterRamenConfig := ramenConfig.DeepCopy()
+		drClusterRamenConfig.RamenControllerType = ramen.DRClusterType
+		drClusterRamenConfig.LeaderElection.ResourceName = "dr-cluster.ramendr.openshift.io"
+
+		configMap, err := controllers.ConfigMapNew(ramenNamespace, controllers.DrClusterOperatorConfigMapName,
+			drClusterRamenConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		parsedRamenConfig, err := controllers.ParseDrClusterConfigMap(configMap)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(parsedRamenConfig.RamenControllerType).To(Equal(ramen.DRClusterType))
+		Expect(parsedRamenConfig.LeaderElection.ResourceName).To(Equal("dr-cluster.ramendr.openshift.io"))
+		Expect(parsedRamenConfig.DrClusterOperator).To(Equal(drClusterRamenConfig.DrClusterOperator))
+	})
+
+	It("fails for a config map without the RamenConfig key", func() {
+		configMap := &corev1.ConfigMap{Data: map[string]string{}}
+
+		_, err := controllers.ParseDrClusterConfigMap(configMap)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("RamenConfigEqual", func() {
+	It("ignores the leader election resource name and controller type", func() {
+		drClusterRamenConfig := ramenConfig.DeepCopy()
+		drClusterRamenConfig.RamenControllerType = ramen.DRClusterType
+		drClusterRamenConfig.LeaderElection.ResourceName = "dr-cluster.ramendr.openshift.io"
+
+		Expect(controllers.RamenConfigEqual(ramenConfig, drClusterRamenConfig)).To(BeTrue())
+	})
+
+	It("detects other differences", func() {
+		changedRamenConfig := ramenConfig.DeepCopy()
+		changedRamenConfig.DrClusterOperator.ChannelName = "changed"
+
+		Expect(controllers.RamenConfigEqual(ramenConfig, changedRamenConfig)).To(BeFalse())
+	})
+
+	It("compares a config map round tripped config as equal", func() {
+		configMap, err := controllers.ConfigMapNew(ramenNamespace, controllers.DrClusterOperatorConfigMapName,
+			ramenConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		parsedRamenConfig, err := controllers.ParseDrClusterConfigMap(configMap)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(controllers.RamenConfigEqual(ramenConfig, parsedRamenConfig)).To(BeTrue())
+	})
+})
+
+var _ = Describe("NeedsConfigUpgrade", func() {
+	configMapNew := func() *corev1.ConfigMap {
+		configMap, err := controllers.ConfigMapNew(ramenNamespace, controllers.DrClusterOperatorConfigMapName,
+			ramenConfig)
+		Expect(err).NotTo(HaveOccurred())
+
+		return configMap
+	}
+
+	It("is not needed for a config map of the desired config and schema version", func() {
+		configMap := configMapNew()
+		Expect(configMap.GetAnnotations()).To(HaveKeyWithValue(controllers.ConfigMapSchemaVersionAnnotation,
+			ramen.RamenConfigSchemaVersion))
+		Expect(controllers.NeedsConfigUpgrade(configMap, ramenConfig)).To(BeFalse())
+	})
+
+	It("is needed for a missing config map", func() {
+		Expect(controllers.NeedsConfigUpgrade(nil, ramenConfig)).To(BeTrue())
+	})
+
+	It("is needed for a config map of another schema version", func() {
+		configMap := configMapNew()
+		configMap.Annotations[controllers.ConfigMapSchemaVersionAnnotation] = "0"
+		Expect(controllers.NeedsConfigUpgrade(configMap, ramenConfig)).To(BeTrue())
+
+		delete(configMap.Annotations, controllers.ConfigMapSchemaVersionAnnotation)
+		Expect(controllers.NeedsConfigUpgrade(configMap, ramenConfig)).To(BeTrue())
+	})
+
+	It("is needed for a changed config", func() {
+		changedRamenConfig := ramenConfig.DeepCopy()
+		changedRamenConfig.DrClusterOperator.ChannelName = "changed"
+
+		Expect(controllers.NeedsConfigUpgrade(configMapNew(), changedRamenConfig)).To(BeTrue())
+	})
+})
+
+var _ = Describe("RamenConfigTemplated", func() {
+	templateRamenConfig := func() *ramen.RamenConfig {
+		templateConfig := ramenConfig.DeepCopy()
+		templateConfig.S3StoreProfiles = append(templateConfig.S3StoreProfiles, ramen.S3StoreProfile{
+			S3ProfileName:        "${CLUSTER_NAME}-profile",
+			S3CompatibleEndpoint: "https://s3.${REGION}.example.com",
+			S3Region:             "${REGION}",
+		})
+
+		return templateConfig
+	}
+
+	It("substitutes the placeholders with the values provided", func() {
+		templateConfig := templateRamenConfig()
+
+		templated, err := controllers.RamenConfigTemplated(templateConfig, map[string]string{
+			controllers.RamenConfigClusterNameValue: "east",
+			"REGION":                                "us-east-1",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		profile := templated.S3StoreProfiles[len(templated.S3StoreProfiles)-1]
+		Expect(profile.S3ProfileName).To(Equal("east-profile"))
+		Expect(profile.S3CompatibleEndpoint).To(Equal("https://s3.us-east-1.example.com"))
+		Expect(profile.S3Region).To(Equal("us-east-1"))
+
+		By("leaving the template unchanged")
+		Expect(templateConfig.S3StoreProfiles[len(templateConfig.S3StoreProfiles)-1].S3Region).To(Equal("${REGION}"))
+	})
+
+	It("returns the config as is without placeholders", func() {
+		templated, err := controllers.RamenConfigTemplated(ramenConfig, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(controllers.RamenConfigEqual(ramenConfig, templated)).To(BeTrue())
+	})
+
+	It("fails naming the placeholders without values", func() {
+		_, err := controllers.RamenConfigTemplated(templateRamenConfig(), map[string]string{
+			controllers.RamenConfigClusterNameValue: "east",
+		})
+		Expect(err).To(MatchError(ContainSubstring("REGION")))
+	})
+})
@@ -22,6 +22,28 @@ func DrpolicyClusterNames(drpolicy *rmn.DRPolicy) []string {
 	return drpolicy.Spec.DRClusters
 }
 
+// GetPeerCluster returns the cluster, other than currentCluster, in a DRPolicy with two clusters. It returns an
+// error if currentCluster is not in the DRPolicy, or if the DRPolicy does not have exactly two clusters, as the
+// peer is then ambiguous.
+func GetPeerCluster(drpolicy *rmn.DRPolicy, currentCluster string) (string, error) {
+	const drpolicyClusterCount = 2
+
+	clusterNames := DrpolicyClusterNames(drpolicy)
+	if len(clusterNames) != drpolicyClusterCount {
+		return "", fmt.Errorf("drpolicy %s has %d clusters, peer cluster is determinable only with %d",
+			drpolicy.Name, len(clusterNames), drpolicyClusterCount)
+	}
+
+	switch currentCluster {
+	case clusterNames[0]:
+		return clusterNames[1], nil
+	case clusterNames[1]:
+		return clusterNames[0], nil
+	}
+
+	return "", fmt.Errorf("cluster %s is not in drpolicy %s clusters %v", currentCluster, drpolicy.Name, clusterNames)
+}
+
 func DrpolicyRegionNames(drpolicy *rmn.DRPolicy, drClusters []rmn.DRCluster) []string {
 	regionNames := make([]string, len(DrpolicyClusterNames(drpolicy)))
 
@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+var _ = Describe("GetPeerCluster", func() {
+	drpolicy := func(clusters ...string) *rmn.DRPolicy {
+		return &rmn.DRPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "drpolicy"},
+			Spec:       rmn.DRPolicySpec{DRClusters: clusters},
+		}
+	}
+
+	It("returns the other cluster of a two cluster policy", func() {
+		Expect(util.GetPeerCluster(drpolicy("east", "west"), "east")).To(Equal("west"))
+		Expect(util.GetPeerCluster(drpolicy("east", "west"), "west")).To(Equal("east"))
+	})
+
+	It("fails for a cluster not in the policy", func() {
+		_, err := util.GetPeerCluster(drpolicy("east", "west"), "north")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("fails for a policy without exactly two clusters", func() {
+		_, err := util.GetPeerCluster(drpolicy("east", "west", "north"), "east")
+		Expect(err).To(HaveOccurred())
+
+		_, err = util.GetPeerCluster(drpolicy("east"), "east")
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -90,6 +90,26 @@ const (
 	// EventReasonSwitchFailed is generated when DRPC fails to switch the cluster
 	// where the app is placed
 	EventReasonSwitchFailed = "DRPCClusterSwitchFailed"
+
+	// Events for ManifestWorks, reported on the object on whose behalf they are managed
+
+	// EventReasonManifestWorkCreated is generated when a ManifestWork is created
+	EventReasonManifestWorkCreated = "ManifestWorkCreated"
+
+	// EventReasonManifestWorkUpdated is generated when a ManifestWork is updated or applied
+	EventReasonManifestWorkUpdated = "ManifestWorkUpdated"
+
+	// EventReasonManifestWorkDeleted is generated when a ManifestWork is deleted
+	EventReasonManifestWorkDeleted = "ManifestWorkDeleted"
+
+	// EventReasonManifestWorkCreateFailed is generated when a ManifestWork fails to be created
+	EventReasonManifestWorkCreateFailed = "ManifestWorkCreateFailed"
+
+	// EventReasonManifestWorkUpdateFailed is generated when a ManifestWork fails to be updated or applied
+	EventReasonManifestWorkUpdateFailed = "ManifestWorkUpdateFailed"
+
+	// EventReasonManifestWorkDeleteFailed is generated when a ManifestWork fails to be deleted
+	EventReasonManifestWorkDeleteFailed = "ManifestWorkDeleteFailed"
 )
 
 // EventReporter is custom events reporter type which allows user to limit the events
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	errorswrapper "github.com/pkg/errors"
@@ -19,12 +20,20 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	csiaddonsv1alpha1 "github.com/csi-addons/kubernetes-csi-addons/apis/csiaddons/v1alpha1"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	rmn "github.com/ramendr/ramen/api/v1alpha1"
 	viewv1beta1 "github.com/stolostron/multicloud-operators-foundation/pkg/apis/view/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 )
 
 // begin MCV code
+
+const (
+	// ManagedClusterView types, in addition to the ManifestWork types, for the OLM resources viewed
+	MCVTypeSubscription = "sub"
+	MCVTypeCSV          = "csv"
+)
+
 type ManagedClusterViewGetter interface {
 	GetVRGFromManagedCluster(
 		resourceName, resourceNamespace, managedCluster string,
@@ -47,6 +56,12 @@ type ManagedClusterViewGetter interface {
 	GetNamespaceFromManagedCluster(resourceName, resourceNamespace, managedCluster string,
 		annotations map[string]string) (*corev1.Namespace, error)
 
+	GetSubscriptionFromManagedCluster(resourceName, resourceNamespace, managedCluster string,
+		annotations map[string]string) (*operatorsv1alpha1.Subscription, error)
+
+	GetInstalledCSVFromManagedCluster(subscription *operatorsv1alpha1.Subscription, managedCluster string,
+		annotations map[string]string) (*operatorsv1alpha1.ClusterServiceVersion, error)
+
 	DeleteVRGManagedClusterView(resourceName, resourceNamespace, clusterName, resourceType string) error
 
 	DeleteNamespaceManagedClusterView(resourceName, resourceNamespace, clusterName, resourceType string) error
@@ -162,6 +177,42 @@ func (m ManagedClusterViewGetterImpl) ListMModesMCVs(cluster string) (*viewv1bet
 	return mModeMCVs, nil
 }
 
+// ErrVRGSyncStatusUnavailable is returned by CompareVRGSyncStatus when the last group sync time of a VRG cannot be
+// read from its cluster
+var ErrVRGSyncStatusUnavailable = errorswrapper.New("VRG sync status unavailable")
+
+// CompareVRGSyncStatus reads the VRG name/namespace from clusterA and clusterB using ManagedClusterViews, and returns
+// whether their last group sync times are the same, and how far the earlier of these lags behind the later one. It
+// returns ErrVRGSyncStatusUnavailable if either VRG cannot be read or has not reported a last group sync time. The
+// lag bounds the data loss of switching from one cluster to the other, e.g. to gate a relocate on the RPO.
+func CompareVRGSyncStatus(getter ManagedClusterViewGetter, name, namespace, clusterA, clusterB string,
+	annotations map[string]string,
+) (bool, time.Duration, error) {
+	syncTimes := make([]time.Time, 0, 2)
+
+	for _, cluster := range []string{clusterA, clusterB} {
+		vrg, err := getter.GetVRGFromManagedCluster(name, namespace, cluster, annotations)
+		if err != nil {
+			return false, 0, fmt.Errorf("cluster %s VRG %s/%s (%v): %w",
+				cluster, namespace, name, err, ErrVRGSyncStatusUnavailable)
+		}
+
+		if vrg.Status.LastGroupSyncTime == nil {
+			return false, 0, fmt.Errorf("cluster %s VRG %s/%s has no last group sync time: %w",
+				cluster, namespace, name, ErrVRGSyncStatusUnavailable)
+		}
+
+		syncTimes = append(syncTimes, vrg.Status.LastGroupSyncTime.Time)
+	}
+
+	lag := syncTimes[0].Sub(syncTimes[1])
+	if lag < 0 {
+		lag = -lag
+	}
+
+	return lag == 0, lag, nil
+}
+
 // outputs a string for use in creating a ManagedClusterView name
 // example: when looking for a vrg with name 'demo' in the namespace 'ramen', input: ("demo", "ramen", "vrg")
 // this will give output "demo-ramen-vrg-mcv"
@@ -210,6 +261,119 @@ func (m ManagedClusterViewGetterImpl) GetNamespaceFromManagedCluster(
 	return namespace, err
 }
 
+func (m ManagedClusterViewGetterImpl) GetSubscriptionFromManagedCluster(
+	resourceName, resourceNamespace, managedCluster string, annotations map[string]string,
+) (*operatorsv1alpha1.Subscription, error) {
+	logger := ctrl.Log.WithName("MCV").WithValues("resourceName", resourceName)
+
+	mcvMeta := metav1.ObjectMeta{
+		Name:        BuildManagedClusterViewName(resourceName, resourceNamespace, MCVTypeSubscription),
+		Namespace:   managedCluster,
+		Annotations: annotations,
+	}
+
+	mcvViewscope := viewv1beta1.ViewScope{
+		Kind:      operatorsv1alpha1.SubscriptionKind,
+		Group:     operatorsv1alpha1.GroupName,
+		Version:   operatorsv1alpha1.GroupVersion,
+		Name:      resourceName,
+		Namespace: resourceNamespace,
+	}
+
+	subscription := &operatorsv1alpha1.Subscription{}
+
+	err := m.getManagedClusterResource(mcvMeta, mcvViewscope, subscription, logger)
+
+	return subscription, err
+}
+
+// GetInstalledCSVFromManagedCluster returns the ClusterServiceVersion installed for the subscription. The
+// ManagedClusterView is named for the subscription, rather than the ClusterServiceVersion, so that it is reused as
+// the subscription is upgraded to later versions.
+func (m ManagedClusterViewGetterImpl) GetInstalledCSVFromManagedCluster(
+	subscription *operatorsv1alpha1.Subscription, managedCluster string, annotations map[string]string,
+) (*operatorsv1alpha1.ClusterServiceVersion, error) {
+	logger := ctrl.Log.WithName("MCV").WithValues("resourceName", subscription.Status.InstalledCSV)
+
+	mcvMeta := metav1.ObjectMeta{
+		Name:        BuildManagedClusterViewName(subscription.GetName(), subscription.GetNamespace(), MCVTypeCSV),
+		Namespace:   managedCluster,
+		Annotations: annotations,
+	}
+
+	mcvViewscope := viewv1beta1.ViewScope{
+		Kind:      operatorsv1alpha1.ClusterServiceVersionKind,
+		Group:     operatorsv1alpha1.GroupName,
+		Version:   operatorsv1alpha1.GroupVersion,
+		Name:      subscription.Status.InstalledCSV,
+		Namespace: subscription.GetNamespace(),
+	}
+
+	csv := &operatorsv1alpha1.ClusterServiceVersion{}
+
+	err := m.getManagedClusterResource(mcvMeta, mcvViewscope, csv, logger)
+
+	return csv, err
+}
+
+// ErrOperatorInstallFailed is returned by VerifyOperatorInstalled when OLM reports that it failed to install the
+// operator
+var ErrOperatorInstallFailed = errorswrapper.New("operator install failed")
+
+// VerifyOperatorInstalled returns true once the ClusterServiceVersion installed for the OLM Subscription
+// name/namespace on cluster reaches the Succeeded phase, as read using ManagedClusterViews. It returns
+// ErrOperatorInstallFailed, along with the OLM reported message, if the Subscription install plan or resolution
+// failed, or if the ClusterServiceVersion failed.
+func VerifyOperatorInstalled(getter ManagedClusterViewGetter, name, namespace, cluster string) (bool, error) {
+	subscription, err := getter.GetSubscriptionFromManagedCluster(name, namespace, cluster, nil)
+	if err != nil {
+		return false, fmt.Errorf("cluster %s subscription %s/%s: %w", cluster, namespace, name, err)
+	}
+
+	for _, condition := range subscription.Status.Conditions {
+		if (condition.Type == operatorsv1alpha1.SubscriptionInstallPlanFailed ||
+			condition.Type == operatorsv1alpha1.SubscriptionResolutionFailed) &&
+			condition.Status == corev1.ConditionTrue {
+			return false, fmt.Errorf("cluster %s subscription %s/%s %s: %s: %w",
+				cluster, namespace, name, condition.Type, condition.Message, ErrOperatorInstallFailed)
+		}
+	}
+
+	if subscription.Status.InstalledCSV == "" {
+		return false, nil
+	}
+
+	csv, err := getter.GetInstalledCSVFromManagedCluster(subscription, cluster, nil)
+	if err != nil {
+		return false, fmt.Errorf("cluster %s csv %s/%s: %w", cluster, namespace, subscription.Status.InstalledCSV, err)
+	}
+
+	switch csv.Status.Phase {
+	case operatorsv1alpha1.CSVPhaseSucceeded:
+		return true, nil
+	case operatorsv1alpha1.CSVPhaseFailed:
+		return false, fmt.Errorf("cluster %s csv %s/%s: %s: %w",
+			cluster, namespace, csv.GetName(), csv.Status.Message, ErrOperatorInstallFailed)
+	default:
+		return false, nil
+	}
+}
+
+// DeleteSubscriptionManagedClusterViews deletes the ManagedClusterViews of the Subscription name/namespace and its
+// installed ClusterServiceVersion on cluster
+func DeleteSubscriptionManagedClusterViews(getter ManagedClusterViewGetter, name, namespace, cluster string) error {
+	logger := ctrl.Log.WithName("MCV").WithValues("resourceName", name)
+
+	for _, mcvType := range []string{MCVTypeSubscription, MCVTypeCSV} {
+		err := getter.DeleteManagedClusterView(cluster, BuildManagedClusterViewName(name, namespace, mcvType), logger)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 /*
 Description: queries a managed cluster for a resource type, and populates a variable with the results.
 Requires:
@@ -0,0 +1,74 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+type vrgMCVGetter struct {
+	util.ManagedClusterViewGetter
+	vrgs map[string]*rmn.VolumeReplicationGroup
+}
+
+func (g vrgMCVGetter) GetVRGFromManagedCluster(resourceName, resourceNamespace, managedCluster string,
+	annotations map[string]string,
+) (*rmn.VolumeReplicationGroup, error) {
+	vrg, ok := g.vrgs[managedCluster]
+	if !ok {
+		return nil, fmt.Errorf("VRG %s/%s not found on cluster %s", resourceNamespace, resourceName, managedCluster)
+	}
+
+	return vrg, nil
+}
+
+var _ = Describe("CompareVRGSyncStatus", func() {
+	vrgSyncedAt := func(syncTime *metav1.Time) *rmn.VolumeReplicationGroup {
+		return &rmn.VolumeReplicationGroup{Status: rmn.VolumeReplicationGroupStatus{LastGroupSyncTime: syncTime}}
+	}
+
+	now := metav1.Now()
+	earlier := metav1.NewTime(now.Add(-5 * time.Minute))
+
+	It("returns the lag between the last group sync times of the VRGs", func() {
+		getter := vrgMCVGetter{vrgs: map[string]*rmn.VolumeReplicationGroup{
+			"east": vrgSyncedAt(&earlier),
+			"west": vrgSyncedAt(&now),
+		}}
+
+		inSync, lag, err := util.CompareVRGSyncStatus(getter, "app", "app-ns", "east", "west", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inSync).To(BeFalse())
+		Expect(lag).To(Equal(5 * time.Minute))
+
+		getter.vrgs["east"] = vrgSyncedAt(&now)
+
+		inSync, lag, err = util.CompareVRGSyncStatus(getter, "app", "app-ns", "east", "west", nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(inSync).To(BeTrue())
+		Expect(lag).To(BeZero())
+	})
+
+	It("returns ErrVRGSyncStatusUnavailable if either VRG status is unavailable", func() {
+		getter := vrgMCVGetter{vrgs: map[string]*rmn.VolumeReplicationGroup{
+			"east": vrgSyncedAt(nil),
+			"west": vrgSyncedAt(&now),
+		}}
+
+		_, _, err := util.CompareVRGSyncStatus(getter, "app", "app-ns", "east", "west", nil)
+		Expect(errors.Is(err, util.ErrVRGSyncStatusUnavailable)).To(BeTrue())
+
+		_, _, err = util.CompareVRGSyncStatus(getter, "app", "app-ns", "west", "north", nil)
+		Expect(errors.Is(err, util.ErrVRGSyncStatusUnavailable)).To(BeTrue())
+	})
+})
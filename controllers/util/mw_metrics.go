@@ -0,0 +1,150 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	ManifestWorkReconcileTotal = "ramen_manifestwork_reconcile_total"
+
+	// ManifestWork reconcile metric labels
+	MWMetricLabelAction = "action"
+	MWMetricLabelType   = "type"
+
+	// ManifestWork reconcile actions
+	MWActionCreate string = "create"
+	MWActionUpdate string = "update"
+	MWActionNoop   string = "noop"
+	MWActionSkip   string = "skip"
+
+	// Type label value for the DRCluster ManifestWork, that does not follow ManifestWorkNameFormat
+	MWTypeDrCluster string = "drcluster"
+
+	VRGManifestGenerationFailuresTotal = "ramen_vrg_manifest_generation_failures_total"
+
+	ManagedManifestWorks = "ramen_managed_manifestworks"
+
+	// Managed ManifestWorks metric label, in addition to MWMetricLabelType
+	MWMetricLabelCluster = "cluster"
+
+	// ManagedManifestWorksRefreshInterval is the interval at which the ManagedManifestWorks gauge is refreshed
+	ManagedManifestWorksRefreshInterval = 5 * time.Minute
+
+	// VRG manifest generation failure metric label, and its values
+	VRGManifestFailureLabelKind             = "kind"
+	VRGManifestFailureKindMarshal    string = "marshal"
+	VRGManifestFailureKindValidation string = "validation"
+)
+
+var manifestWorkReconcileTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: ManifestWorkReconcileTotal,
+		Help: "Number of ManifestWork create, update, no-op, server-side apply and skip decisions",
+	},
+	[]string{
+		MWMetricLabelAction, // [create|update|noop|apply|skip]
+		MWMetricLabelType,   // ManifestWork type [vrg|ns|nf|mmode|drcluster]
+	},
+)
+
+var vrgManifestGenerationFailuresTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: VRGManifestGenerationFailuresTotal,
+		Help: "Number of failures generating the VRG manifest for a VRG ManifestWork",
+	},
+	[]string{
+		VRGManifestFailureLabelKind, // [marshal|validation]
+	},
+)
+
+// managedManifestWorks is refreshed by periodically listing the ManifestWorks, rather than tracked as MWUtil creates
+// and deletes them, as MWUtil instances are short lived, ManifestWorks are also deleted by others, such as OCM when
+// a cluster is detached, and counts tracked in memory are lost on restart. A periodic list corrects for all of these.
+var managedManifestWorks = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: ManagedManifestWorks,
+		Help: "Number of ManifestWorks managed by Ramen, as of the last periodic list",
+	},
+	[]string{
+		MWMetricLabelType,    // ManifestWork type [vrg|ns|nf|mmode|drcluster]
+		MWMetricLabelCluster, // Managed cluster name
+	},
+)
+
+func init() {
+	metrics.Registry.MustRegister(manifestWorkReconcileTotal, vrgManifestGenerationFailuresTotal, managedManifestWorks)
+}
+
+// RefreshManagedManifestWorksMetric lists the ManifestWorks managed by Ramen across all clusters, and sets the
+// ManagedManifestWorks gauge to their count per type and cluster
+func (mwu *MWUtil) RefreshManagedManifestWorksMetric() error {
+	mws, err := mwu.listManifestWorks(metav1.NamespaceAll, nil, IsManifestWorkManagedByRamen)
+	if err != nil {
+		return err
+	}
+
+	type typeCluster struct{ mwType, cluster string }
+
+	counts := map[typeCluster]float64{}
+	for i := range mws {
+		counts[typeCluster{manifestWorkTypeFromName(mws[i].GetName()), ManifestWorkCluster(&mws[i])}]++
+	}
+
+	// Reset drops the counts of types and clusters that no longer have ManifestWorks
+	managedManifestWorks.Reset()
+
+	for key, count := range counts {
+		managedManifestWorks.With(prometheus.Labels{
+			MWMetricLabelType:    key.mwType,
+			MWMetricLabelCluster: key.cluster,
+		}).Set(count)
+	}
+
+	return nil
+}
+
+// RefreshManagedManifestWorksMetricPeriodically refreshes the ManagedManifestWorks gauge every interval until
+// mwu.Ctx is done, logging errors to retry at the next interval. It is meant to be run by the manager.
+func (mwu *MWUtil) RefreshManagedManifestWorksMetricPeriodically(interval time.Duration) {
+	wait.UntilWithContext(mwu.Ctx, func(context.Context) {
+		if err := mwu.RefreshManagedManifestWorksMetric(); err != nil {
+			mwu.Log.Error(err, "failed to refresh managed ManifestWorks metric")
+		}
+	}, interval)
+}
+
+func vrgManifestGenerationFailureCountIncrement(kind string) {
+	vrgManifestGenerationFailuresTotal.With(prometheus.Labels{VRGManifestFailureLabelKind: kind}).Inc()
+}
+
+func manifestWorkReconcileCountIncrement(action string, mwName string) {
+	manifestWorkReconcileTotal.With(prometheus.Labels{
+		MWMetricLabelAction: action,
+		MWMetricLabelType:   manifestWorkTypeFromName(mwName),
+	}).Inc()
+}
+
+// manifestWorkTypeFromName returns the type portion of a ManifestWork name generated using either
+// ManifestWorkNameFormat or ManifestWorkNameFormatClusterScope, i.e the segment preceding the "-mw" suffix
+func manifestWorkTypeFromName(mwName string) string {
+	if mwName == DrClusterManifestWorkName {
+		return MWTypeDrCluster
+	}
+
+	trimmed := strings.TrimSuffix(mwName, "-mw")
+	if trimmed == mwName {
+		return "unknown"
+	}
+
+	return trimmed[strings.LastIndex(trimmed, "-")+1:]
+}
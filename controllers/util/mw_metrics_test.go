@@ -0,0 +1,212 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+var _ = Describe("GetMetricValueFrom", func() {
+	It("reads a value from a registry other than the global one", func() {
+		reg := prometheus.NewRegistry()
+		gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "ramen_test_local_gauge",
+			Help: "Test Gauge registered on a local registry",
+		})
+		reg.MustRegister(gauge)
+		gauge.Set(42)
+
+		val, err := rmnutil.GetMetricValueFrom(reg, "ramen_test_local_gauge", dto.MetricType_GAUGE)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(val).To(Equal(42.0))
+
+		_, err = rmnutil.GetMetricValueSingle("ramen_test_local_gauge", dto.MetricType_GAUGE)
+		Expect(errors.Is(err, rmnutil.ErrMetricNotFound)).To(BeTrue())
+	})
+
+	It("distinguishes an empty registry from a missing metric", func() {
+		reg := prometheus.NewRegistry()
+
+		_, err := rmnutil.GetMetricValueFrom(reg, "ramen_test_local_gauge", dto.MetricType_GAUGE)
+		Expect(errors.Is(err, rmnutil.ErrNoMetricsRegistered)).To(BeTrue())
+
+		reg.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "ramen_test_other_gauge",
+			Help: "Test Gauge registered on a local registry",
+		}))
+
+		_, err = rmnutil.GetMetricValueFrom(reg, "ramen_test_local_gauge", dto.MetricType_GAUGE)
+		Expect(errors.Is(err, rmnutil.ErrMetricNotFound)).To(BeTrue())
+	})
+})
+
+var _ = Describe("ListRegisteredMetricNames", func() {
+	It("lists the sorted names of the metrics in a registry, filtered by prefix", func() {
+		reg := prometheus.NewRegistry()
+
+		names, err := rmnutil.ListRegisteredMetricNamesFrom(reg, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(BeEmpty())
+
+		for _, name := range []string{"ramen_test_b_gauge", "other_test_gauge", "ramen_test_a_gauge"} {
+			reg.MustRegister(prometheus.NewGauge(prometheus.GaugeOpts{
+				Name: name,
+				Help: "Test Gauge registered on a local registry",
+			}))
+		}
+
+		names, err = rmnutil.ListRegisteredMetricNamesFrom(reg, rmnutil.RamenMetricPrefix)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"ramen_test_a_gauge", "ramen_test_b_gauge"}))
+
+		names, err = rmnutil.ListRegisteredMetricNamesFrom(reg, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(HaveLen(3))
+	})
+
+	It("lists the metrics registered in the controller-runtime registry", func() {
+		names, err := rmnutil.ListRegisteredMetricNames(rmnutil.RamenMetricPrefix)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(ContainElement("ramen_test_gauge"))
+	})
+})
+
+var _ = Describe("CounterTracker", func() {
+	It("returns the increase of a counter since the baseline across registry resets", func() {
+		const name = "ramen_test_tracked_counter"
+
+		newCounter := func() prometheus.Counter {
+			return prometheus.NewCounter(prometheus.CounterOpts{
+				Name: name,
+				Help: "Test Counter registered on a local registry",
+			})
+		}
+
+		reg := prometheus.NewRegistry()
+		counter := newCounter()
+		reg.MustRegister(counter)
+		counter.Add(5)
+
+		tracker, err := rmnutil.NewCounterTrackerFrom(reg, name)
+		Expect(err).NotTo(HaveOccurred())
+
+		counter.Add(2)
+		Expect(tracker.Delta()).To(Equal(2.0))
+
+		Expect(reg.Unregister(counter)).To(BeTrue())
+		counter = newCounter()
+		reg.MustRegister(counter)
+		counter.Add(1)
+		Expect(tracker.Delta()).To(Equal(3.0))
+
+		counter.Add(1)
+		Expect(tracker.Delta()).To(Equal(4.0))
+	})
+})
+
+var _ = Describe("MetricDelta", func() {
+	It("returns the change in a metric across the action, reading a missing metric as 0", func() {
+		reg := prometheus.NewRegistry()
+		counter := prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "ramen_test_delta_counter",
+			Help: "Test Counter registered on a local registry",
+		})
+
+		delta, err := rmnutil.MetricDeltaFrom(reg, "ramen_test_delta_counter", dto.MetricType_COUNTER, func() error {
+			reg.MustRegister(counter)
+			counter.Add(3)
+
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(delta).To(Equal(3.0))
+
+		delta, err = rmnutil.MetricDeltaFrom(reg, "ramen_test_delta_counter", dto.MetricType_COUNTER, func() error {
+			counter.Inc()
+
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(delta).To(Equal(1.0))
+	})
+
+	It("returns the error from the action", func() {
+		reg := prometheus.NewRegistry()
+
+		_, err := rmnutil.MetricDeltaFrom(reg, "ramen_test_delta_counter", dto.MetricType_COUNTER, func() error {
+			return fmt.Errorf("action failed")
+		})
+		Expect(err).To(MatchError("action failed"))
+	})
+})
+
+var _ = Describe("ManifestWorkReconcileTotal", func() {
+	const clusterName = "mw-metrics-cluster"
+
+	var mwu *rmnutil.MWUtil
+
+	mwReconcileCount := func(action string) float64 {
+		val, err := rmnutil.GetMetricValueWithLabels(rmnutil.ManifestWorkReconcileTotal, dto.MetricType_COUNTER,
+			map[string]string{
+				rmnutil.MWMetricLabelAction: action,
+				rmnutil.MWMetricLabelType:   rmnutil.MWTypeNS,
+			})
+		if err != nil {
+			return 0
+		}
+
+		return val
+	}
+
+	BeforeEach(func() {
+		createClusterNamespace(clusterName)
+
+		mwu = newTestMWUtil(func(m *rmnutil.MWUtil) {
+			m.InstName = "metrics"
+			m.TargetNamespace = "metrics-ns"
+		})
+	})
+
+	It("counts a create followed by a no-op once converged", func() {
+		creates := mwReconcileCount(rmnutil.MWActionCreate)
+		noops := mwReconcileCount(rmnutil.MWActionNoop)
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("metrics", "metrics-ns", clusterName, nil, nil, nil)).To(Succeed())
+		Expect(mwReconcileCount(rmnutil.MWActionCreate)).To(Equal(creates + 1))
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("metrics", "metrics-ns", clusterName, nil, nil, nil)).To(Succeed())
+		Expect(mwReconcileCount(rmnutil.MWActionNoop)).To(Equal(noops + 1))
+		Expect(mwReconcileCount(rmnutil.MWActionCreate)).To(Equal(creates + 1))
+	})
+})
+
+var _ = Describe("ManagedManifestWorks", func() {
+	const clusterName = "mw-managed-metric-cluster"
+
+	It("counts the ManifestWorks managed by Ramen per type and cluster", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		for _, name := range []string{"managed-a", "managed-b"} {
+			Expect(mwu.CreateOrUpdateNamespaceManifest(name, name+"-ns", clusterName, nil, nil, nil)).To(Succeed())
+		}
+
+		Expect(mwu.RefreshManagedManifestWorksMetric()).To(Succeed())
+
+		Expect(rmnutil.GetMetricValueWithLabels(rmnutil.ManagedManifestWorks, dto.MetricType_GAUGE,
+			map[string]string{
+				rmnutil.MWMetricLabelType:    rmnutil.MWTypeNS,
+				rmnutil.MWMetricLabelCluster: clusterName,
+			})).To(Equal(2.0))
+	})
+})
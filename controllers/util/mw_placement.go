@@ -0,0 +1,125 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"fmt"
+
+	clrapiv1beta1 "github.com/open-cluster-management-io/api/cluster/v1beta1"
+	errorswrapper "github.com/pkg/errors"
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	plrv1 "github.com/stolostron/multicloud-operators-placementrule/pkg/apis/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	PlacementKind     = "Placement"
+	PlacementRuleKind = "PlacementRule"
+)
+
+// ErrPlacementDecisionPending is returned when a Placement or PlacementRule has not decided on any cluster yet,
+// callers should requeue and retry once the decision is made
+var ErrPlacementDecisionPending = errorswrapper.New("placement decision pending")
+
+// ResolveClustersFromPlacement returns the clusters decided by the Placement or PlacementRule referenced by
+// placementRef, as reported in its decision status at the time of the call. ErrPlacementDecisionPending is returned
+// if no cluster is decided yet.
+func (mwu *MWUtil) ResolveClustersFromPlacement(placementRef corev1.ObjectReference) ([]string, error) {
+	var (
+		clusters []string
+		err      error
+	)
+
+	switch placementRef.Kind {
+	case PlacementKind:
+		clusters, err = mwu.placementDecisionClusters(placementRef)
+	case PlacementRuleKind:
+		clusters, err = mwu.placementRuleDecisionClusters(placementRef)
+	default:
+		return nil, fmt.Errorf("unsupported placement kind %q for %s/%s", placementRef.Kind,
+			placementRef.Namespace, placementRef.Name)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(clusters) == 0 {
+		return nil, fmt.Errorf("%s %s/%s: %w", placementRef.Kind, placementRef.Namespace, placementRef.Name,
+			ErrPlacementDecisionPending)
+	}
+
+	return clusters, nil
+}
+
+func (mwu *MWUtil) placementDecisionClusters(placementRef corev1.ObjectReference) ([]string, error) {
+	plDecisions := &clrapiv1beta1.PlacementDecisionList{}
+
+	if err := mwu.Client.List(mwu.Ctx, plDecisions,
+		client.InNamespace(placementRef.Namespace),
+		client.MatchingLabels{clrapiv1beta1.PlacementLabel: placementRef.Name},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list PlacementDecisions for Placement %s/%s: %w",
+			placementRef.Namespace, placementRef.Name, err)
+	}
+
+	clusters := []string{}
+
+	for i := range plDecisions.Items {
+		for _, decision := range plDecisions.Items[i].Status.Decisions {
+			clusters = append(clusters, decision.ClusterName)
+		}
+	}
+
+	return clusters, nil
+}
+
+func (mwu *MWUtil) placementRuleDecisionClusters(placementRef corev1.ObjectReference) ([]string, error) {
+	plRule := &plrv1.PlacementRule{}
+
+	if err := mwu.Client.Get(mwu.Ctx,
+		types.NamespacedName{Name: placementRef.Name, Namespace: placementRef.Namespace}, plRule,
+	); err != nil {
+		return nil, fmt.Errorf("failed to get PlacementRule %s/%s: %w", placementRef.Namespace, placementRef.Name, err)
+	}
+
+	clusters := []string{}
+
+	for _, decision := range plRule.Status.Decisions {
+		clusters = append(clusters, decision.ClusterName)
+	}
+
+	return clusters, nil
+}
+
+// CreateOrUpdateVRGManifestWorkForPlacement creates or updates the VRG ManifestWork on each of the clusters decided
+// by the Placement or PlacementRule referenced by placementRef, as resolved by ResolveClustersFromPlacement. A Primary
+// VRG is only created or updated on a single cluster, ErrMultiplePrimaryClusters is returned for more than one
+// cluster decided, without any change, as it would otherwise lead to a split brain.
+func (mwu *MWUtil) CreateOrUpdateVRGManifestWorkForPlacement(
+	name, namespace string, placementRef corev1.ObjectReference,
+	vrg rmn.VolumeReplicationGroup, annotations map[string]string,
+	forceResync bool, opts ...VRGOption,
+) error {
+	clusters, err := mwu.ResolveClustersFromPlacement(placementRef)
+	if err != nil {
+		return err
+	}
+
+	if vrg.Spec.ReplicationState == rmn.Primary && len(clusters) > 1 {
+		return fmt.Errorf("%s %s/%s clusters %v: %w", placementRef.Kind, placementRef.Namespace, placementRef.Name,
+			clusters, ErrMultiplePrimaryClusters)
+	}
+
+	for _, cluster := range clusters {
+		if err := mwu.CreateOrUpdateVRGManifestWork(name, namespace, cluster, vrg, annotations, forceResync,
+			opts...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	clrapiv1beta1 "github.com/open-cluster-management-io/api/cluster/v1beta1"
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+	plrv1 "github.com/stolostron/multicloud-operators-placementrule/pkg/apis/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("ResolveClustersFromPlacement", func() {
+	const (
+		placementNamespace = "mw-placement-ns"
+		clusterName        = "mw-placement-cluster"
+	)
+
+	var mwu *rmnutil.MWUtil
+
+	BeforeEach(func() {
+		createNamespace(placementNamespace)
+		createClusterNamespace(clusterName)
+
+		mwu = newTestMWUtil()
+	})
+
+	It("resolves the clusters decided for a Placement", func() {
+		plDecision := &clrapiv1beta1.PlacementDecision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "placement-decision-1",
+				Namespace: placementNamespace,
+				Labels:    map[string]string{clrapiv1beta1.PlacementLabel: "placement"},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), plDecision)).To(Succeed())
+
+		placementRef := corev1.ObjectReference{
+			Kind: rmnutil.PlacementKind, Name: "placement", Namespace: placementNamespace,
+		}
+
+		_, err := mwu.ResolveClustersFromPlacement(placementRef)
+		Expect(errors.Is(err, rmnutil.ErrPlacementDecisionPending)).To(BeTrue())
+
+		plDecision.Status.Decisions = []clrapiv1beta1.ClusterDecision{{ClusterName: clusterName, Reason: "test"}}
+		Expect(k8sClient.Status().Update(context.TODO(), plDecision)).To(Succeed())
+
+		Expect(mwu.ResolveClustersFromPlacement(placementRef)).To(Equal([]string{clusterName}))
+	})
+
+	It("creates the VRG ManifestWork on the cluster decided for a PlacementRule", func() {
+		plRule := &plrv1.PlacementRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "placement-rule", Namespace: placementNamespace},
+		}
+		Expect(k8sClient.Create(context.TODO(), plRule)).To(Succeed())
+
+		placementRef := corev1.ObjectReference{
+			Kind: rmnutil.PlacementRuleKind, Name: "placement-rule", Namespace: placementNamespace,
+		}
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "placement", Namespace: "placement-ns"},
+			Spec:       validVRGSpec(),
+		}
+
+		err := mwu.CreateOrUpdateVRGManifestWorkForPlacement("placement", "placement-ns", placementRef, vrg, nil, false)
+		Expect(errors.Is(err, rmnutil.ErrPlacementDecisionPending)).To(BeTrue())
+
+		plRule.Status.Decisions = []plrv1.PlacementDecision{{ClusterName: clusterName, ClusterNamespace: clusterName}}
+		Expect(k8sClient.Status().Update(context.TODO(), plRule)).To(Succeed())
+
+		Expect(mwu.CreateOrUpdateVRGManifestWorkForPlacement("placement", "placement-ns", placementRef, vrg, nil,
+			false)).To(Succeed())
+
+		_, err = mwu.FindManifestWork(rmnutil.ManifestWorkName("placement", "placement-ns", rmnutil.MWTypeVRG),
+			clusterName)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("does not create a Primary VRG ManifestWork on more than one cluster decided", func() {
+		const otherClusterName = "mw-placement-cluster-other"
+
+		createClusterNamespace(otherClusterName)
+
+		plRule := &plrv1.PlacementRule{
+			ObjectMeta: metav1.ObjectMeta{Name: "placement-rule-multiple", Namespace: placementNamespace},
+		}
+		Expect(k8sClient.Create(context.TODO(), plRule)).To(Succeed())
+
+		plRule.Status.Decisions = []plrv1.PlacementDecision{
+			{ClusterName: clusterName, ClusterNamespace: clusterName},
+			{ClusterName: otherClusterName, ClusterNamespace: otherClusterName},
+		}
+		Expect(k8sClient.Status().Update(context.TODO(), plRule)).To(Succeed())
+
+		placementRef := corev1.ObjectReference{
+			Kind: rmnutil.PlacementRuleKind, Name: "placement-rule-multiple", Namespace: placementNamespace,
+		}
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "placement-multiple", Namespace: "placement-ns"},
+			Spec:       validVRGSpec(),
+		}
+		mwName := rmnutil.ManifestWorkName("placement-multiple", "placement-ns", rmnutil.MWTypeVRG)
+
+		err := mwu.CreateOrUpdateVRGManifestWorkForPlacement("placement-multiple", "placement-ns", placementRef, vrg,
+			nil, false)
+		Expect(errors.Is(err, rmnutil.ErrMultiplePrimaryClusters)).To(BeTrue())
+
+		for _, cluster := range []string{clusterName, otherClusterName} {
+			_, err = mwu.FindManifestWork(mwName, cluster)
+			Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		}
+
+		vrg.Spec.ReplicationState = rmn.Secondary
+		Expect(mwu.CreateOrUpdateVRGManifestWorkForPlacement("placement-multiple", "placement-ns", placementRef, vrg,
+			nil, false)).To(Succeed())
+
+		for _, cluster := range []string{clusterName, otherClusterName} {
+			_, err = mwu.FindManifestWork(mwName, cluster)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	})
+
+	It("fails for an unsupported placement kind", func() {
+		_, err := mwu.ResolveClustersFromPlacement(corev1.ObjectReference{Kind: "Deployment", Name: "placement"})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -4,10 +4,17 @@
 package util
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
 	ocmworkv1 "github.com/open-cluster-management/api/work/v1"
@@ -15,14 +22,25 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/yaml"
 
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
 	dto "github.com/prometheus/client_model/go"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
@@ -46,15 +64,416 @@ const (
 	MWTypeNS    string = "ns"
 	MWTypeNF    string = "nf"
 	MWTypeMMode string = "mmode"
+
+	// ForceResyncAnnotation on the VRG, set to a new timestamp, requests an immediate resync on the managed cluster
+	ForceResyncAnnotation = "ramendr.openshift.io/force-resync"
+
+	// ReapplyAnnotation on the VRG, set to a new timestamp, forces OCM to re-apply the VRG ManifestWork even when
+	// the desired VRG is otherwise unchanged
+	ReapplyAnnotation = "ramendr.openshift.io/reapply"
+
+	// HubNameAnnotation on the objects in the generated manifests identifies the hub that generated them
+	HubNameAnnotation = "ramendr.openshift.io/hub-name"
+
+	// ManifestWorkSizeLimit is the total size in bytes of manifests in a ManifestWork that the OCM ManifestWork
+	// webhook admits, well within the etcd object size limit
+	ManifestWorkSizeLimit = 500 * 1024
+
+	// MWFieldManager is the field manager used when applying ManifestWorks using server-side apply
+	MWFieldManager = "ramen-hub"
+
+	// manifestWorkPollInterval is the interval at which WaitForManifestWorkDeleted and WaitForManifestWorkApplied
+	// check the ManifestWork
+	manifestWorkPollInterval = time.Second
+
+	// manifestWorkListPageSize is the number of ManifestWorks fetched per list request when paginating
+	manifestWorkListPageSize = 100
+
+	// manifestWorkRewatchDelay is the delay before WatchManifestWork re-establishes a watch that ended
+	manifestWorkRewatchDelay = time.Second
+
+	// ManifestWorkPendingRequeueDelay is the suggested requeue delay when waiting on a ManifestWork state change
+	// that the work agent is expected to make shortly, such as applying or deleting a ManifestWork
+	ManifestWorkPendingRequeueDelay = 5 * time.Second
+
+	// ClusterUnreachableRequeueDelay is the suggested requeue delay when the ManifestWorks of a cluster cannot be
+	// reached, as recovery is not expected to be immediate
+	ClusterUnreachableRequeueDelay = time.Minute
+
+	// Annotations for MW and PlacementRule
+	DRPCNameAnnotation      = "drplacementcontrol.ramendr.openshift.io/drpc-name"
+	DRPCNamespaceAnnotation = "drplacementcontrol.ramendr.openshift.io/drpc-namespace"
+
+	// Annotation for MWs created on behalf of a DRCluster
+	DRClusterNameAnnotation = "drcluster.ramendr.openshift.io/drcluster-name"
+
+	// Annotation used by older releases for the DRCluster MW
+	drClusterNameAnnotationLegacy = "DRClusterName"
+
+	// Label, and its value, identifying MWs created by Ramen
+	ManagedByLabel      = "app.kubernetes.io/managed-by"
+	ManagedByLabelValue = "ramen"
+
+	// OperationIDAnnotation on MWs correlates all MWs created or updated during a single DR operation
+	OperationIDAnnotation = "ramendr.openshift.io/operation-id"
+
+	// SpecHashAnnotation on MWs records the ManifestWorkSpecHash of the MW spec as last created or updated by Ramen.
+	// It does not reflect changes made to the spec by others, hence is not relied upon to detect them.
+	SpecHashAnnotation = "ramendr.openshift.io/spec-hash"
+
+	// SourceGenerationAnnotation on VRG MWs records the MWUtil SourceGeneration, and VRG replication state, the MW was
+	// last created or updated for
+	SourceGenerationAnnotation = "ramendr.openshift.io/source-generation"
+
+	// ManifestWorkProtectionFinalizer on MWs retains them until Ramen removes it, when MWUtil.ProtectionFinalizer
+	// is set
+	ManifestWorkProtectionFinalizer = "ramendr.openshift.io/manifestwork-protection"
+
+	// ManifestWorksPausedAnnotation on a DRCluster, set to ManifestWorksPausedValue, pauses the creation, update and
+	// deletion of ManifestWorks for the cluster, e.g. during a maintenance window. Deletions while finalizing a DRPC
+	// or DRCluster are not paused.
+	ManifestWorksPausedAnnotation = "drcluster.ramendr.openshift.io/manifestworks-paused"
+	ManifestWorksPausedValue      = "true"
+
+	// ExportedFromClusterAnnotation on objects exported by ExportDRPCManifestWorks records the cluster whose
+	// ManifestWork embedded the object
+	ExportedFromClusterAnnotation = "ramendr.openshift.io/exported-from-cluster"
+)
+
+var (
+	// ErrManifestWorkNotManaged is returned when refusing to delete a ManifestWork not created by Ramen
+	ErrManifestWorkNotManaged = errorswrapper.New("ManifestWork is not managed by Ramen")
+
+	// ErrManifestWorkTerminating is returned when a ManifestWork to be updated is being deleted, callers should
+	// requeue and recreate it once the deletion completes
+	ErrManifestWorkTerminating = errorswrapper.New("ManifestWork is being deleted")
+
+	// ErrNoMetricsRegistered is returned when gathering from a registry with no metrics registered
+	ErrNoMetricsRegistered = errorswrapper.New("no metrics registered")
+
+	// ErrMetricNotFound is returned when the requested metric is not registered
+	ErrMetricNotFound = errorswrapper.New("metric not found")
+
+	// ErrManifestWorkMigrationPending is returned when a ManifestWork migrated to a new name is not yet applied,
+	// and hence the ManifestWork with the old name is retained
+	ErrManifestWorkMigrationPending = errorswrapper.New("ManifestWork migration pending")
+
+	// ErrManifestWorkRelocationPending is returned when a ManifestWork relocated to a new cluster is not yet applied,
+	// and hence the ManifestWork on the old cluster is retained
+	ErrManifestWorkRelocationPending = errorswrapper.New("ManifestWork relocation pending")
+
+	// ErrSourceVRGNotDemoted is returned when promoting a VRG on a cluster before the VRG on the cluster it is
+	// failing over from is demoted to Secondary
+	ErrSourceVRGNotDemoted = errorswrapper.New("source VRG not yet demoted")
+
+	// ErrClusterDrainPending is returned when VRGs on a cluster being drained are not yet demoted
+	ErrClusterDrainPending = errorswrapper.New("cluster drain pending")
+
+	// ErrManifestWorkTooLarge is returned when the manifests in a ManifestWork exceed ManifestWorkSizeLimit
+	ErrManifestWorkTooLarge = errorswrapper.New("ManifestWork manifests too large")
+
+	// ErrManifestWorkConditionNotMet is returned when a conditional ManifestWork delete is skipped
+	ErrManifestWorkConditionNotMet = errorswrapper.New("ManifestWork delete condition not met")
+
+	// ErrClusterUnreachable is returned when the hub cannot reach the ManifestWorks in a cluster namespace
+	ErrClusterUnreachable = errorswrapper.New("cluster unreachable")
+
+	// ErrManifestWorkPartialUpdate is returned when a ManifestWork cannot be updated in part, and should instead be
+	// regenerated in full
+	ErrManifestWorkPartialUpdate = errorswrapper.New("ManifestWork partial update not possible")
+
+	// ErrNoPrimaryCluster is returned when none of the VRG ManifestWorks of a DRPC carry a Primary VRG
+	ErrNoPrimaryCluster = errorswrapper.New("no cluster has a Primary VRG")
+
+	// ErrMultiplePrimaryClusters is returned when more than one VRG ManifestWork of a DRPC carries a Primary VRG, or
+	// would for the clusters decided by a placement
+	ErrMultiplePrimaryClusters = errorswrapper.New("multiple clusters have a Primary VRG")
+
+	// ErrVRGManifestNotFound is returned when a ManifestWork does not contain a VolumeReplicationGroup manifest
+	ErrVRGManifestNotFound = errorswrapper.New("VolumeReplicationGroup manifest not found in ManifestWork")
+
+	// ErrDrClusterRBACDegraded is returned when the RBAC resources in the DRCluster ManifestWork failed to apply
+	ErrDrClusterRBACDegraded = errorswrapper.New("DRCluster RBAC resources degraded")
+
+	// ErrClusterReconciliationPaused is returned instead of creating, updating or deleting a ManifestWork for a
+	// cluster whose DRCluster carries the ManifestWorksPausedAnnotation
+	ErrClusterReconciliationPaused = errorswrapper.New("cluster reconciliation paused")
+
+	// ErrClusterNamespaceMissing is returned instead of creating a ManifestWork for a cluster whose hub namespace
+	// does not exist, as is the case until the cluster is registered with OCM
+	ErrClusterNamespaceMissing = errorswrapper.New("cluster namespace missing")
+
+	// ErrUnexpectedManifestKind is returned when generating a manifest for an object whose kind Ramen does not ship
+	// to managed clusters, see RegisterManifestGVKs
+	ErrUnexpectedManifestKind = errorswrapper.New("unexpected manifest kind")
+
+	// ErrOCMNotInstalled is returned when the hub does not serve the OCM work API, and hence ManifestWorks
+	ErrOCMNotInstalled = errorswrapper.New("OCM not installed, ManifestWork API is not available")
+
+	// ErrS3ProfileNotDefined is returned when a VRG references an S3 profile missing from the hub RamenConfig
+	ErrS3ProfileNotDefined = errorswrapper.New("s3 profile not defined in ramen config")
 )
 
+// ManifestWorkNameFunc generates a ManifestWork name given the DRPC name, the VRG namespace and the ManifestWork type
+type ManifestWorkNameFunc func(name, namespace, mwType string) string
+
+// ManifestWorkClient is the subset of client.Client used by MWUtil, which a controller-runtime client satisfies
+type ManifestWorkClient interface {
+	Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error
+	List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error
+	Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error
+	Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error
+	Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error
+	Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error
+}
+
+var _ ManifestWorkClient = client.Client(nil)
+
+// ManifestWorkManager generates, applies and removes the ManifestWorks Ramen deploys to managed clusters. MWUtil
+// implements it, components that only need these operations should depend on it instead, to permit substituting a
+// fake in tests without an API server.
+type ManifestWorkManager interface {
+	CreateOrUpdateVRGManifestWork(
+		name, namespace, homeCluster string,
+		vrg rmn.VolumeReplicationGroup, annotations map[string]string,
+		forceResync bool, opts ...VRGOption,
+	) error
+
+	CreateOrUpdateNamespaceManifest(
+		name string, namespaceName string, managedClusterNamespace string,
+		annotations map[string]string, namespaceLabels, namespaceAnnotations map[string]string,
+	) error
+
+	CreateOrUpdateDrClusterManifestWork(
+		clusterName string,
+		objectsToAppend []interface{}, annotations map[string]string,
+		extraManifests ...runtime.RawExtension,
+	) error
+
+	DeleteManifestWork(mwName, cluster string) error
+
+	FindManifestWork(mwName, managedCluster string) (*ocmworkv1.ManifestWork, error)
+
+	WaitForManifestWorkApplied(mwName, cluster string, timeout time.Duration) error
+}
+
+var _ ManifestWorkManager = &MWUtil{}
+
+// MWUtil creates, updates and deletes ManifestWorks on behalf of a Ramen resource instance. Prefer NewMWUtil over
+// populating the struct directly, to ensure its required fields are set.
 type MWUtil struct {
-	client.Client
+	Client          ManifestWorkClient
 	APIReader       client.Reader
 	Ctx             context.Context
 	Log             logr.Logger
 	InstName        string
 	TargetNamespace string
+
+	// ServerSideApply, when set, applies ManifestWorks using server-side apply instead of a Create or Update. The
+	// latter is retained for clusters with API servers that lack server-side apply. The ManifestWork is still read
+	// first, so that a terminating or unchanged ManifestWork is not applied. Ownership of fields set by another
+	// field manager is not forced, instead the conflict is returned as an error.
+	ServerSideApply bool
+
+	// EventRecorder, if set, reports events on EventObject as ManifestWorks are created, updated and deleted
+	EventRecorder *EventReporter
+	EventObject   runtime.Object
+
+	// OperationID is stamped on ManifestWorks as the OperationIDAnnotation, to correlate them across clusters.
+	// Callers reconciling a DR operation set it to a value stable across the reconciles of the operation. A UUID is
+	// generated on first use if unset, for one-off callers.
+	OperationID string
+
+	// PreserveManifestStatus, if set, retains the status of objects in the manifests generated for them
+	PreserveManifestStatus bool
+
+	// LocalClusterName, if set, is the name of the hub as a managed cluster, for single cluster deployments without
+	// OCM. VRGs for this cluster are created and updated directly, instead of being wrapped in a ManifestWork.
+	LocalClusterName string
+
+	// WatchClient, if set, is used by WatchManifestWork to watch ManifestWorks
+	WatchClient client.WithWatch
+
+	// ProtectionFinalizer, if set, adds ManifestWorkProtectionFinalizer to the ManifestWorks created or updated, so
+	// that a ManifestWork deleted other than by DeleteManifestWork is retained until RemoveManifestWorkFinalizer is
+	// called, e.g. once its VRG is demoted
+	ProtectionFinalizer bool
+
+	// Finalizing, if set, deletes ManifestWorks and removes their ManifestWorkProtectionFinalizer for a paused
+	// cluster as well. Callers finalizing a DRPC or DRCluster set it, as the deletion would otherwise not complete
+	// until the cluster is unpaused.
+	Finalizing bool
+
+	// VRGManifestWorkLabels, if set, are added to the labels of the VRG ManifestWorks created or updated, e.g. a
+	// tenant label for monitoring. They do not override the labels set by Ramen, such as ManagedByLabel.
+	VRGManifestWorkLabels map[string]string
+
+	// SourceGeneration, if set, identifies the version of the inputs the VRG ManifestWork is generated from, e.g. the
+	// generations of the DRPC and its DRPolicy and DRClusters and the RamenConfig resource version. It must change
+	// whenever any of these do. It is stamped on the ManifestWork as the SourceGenerationAnnotation, and
+	// EnsureVRGManifestWork skips generating the VRG manifest for an existing ManifestWork stamped with the same
+	// value, for the same VRG replication state. Updates of the VRG other than by EnsureVRGManifestWork, such as
+	// SetVRGActionInManifestWork, drop the stamp.
+	SourceGeneration string
+
+	// HubName, if set, is stamped as the HubNameAnnotation on every object in the manifests generated, VRGs included,
+	// identifying the hub, by its cluster name or UID, that owns them. Where multiple hubs may manage the same
+	// cluster, e.g. during a hub recovery, it allows the managed cluster to reject objects from an unexpected hub.
+	HubName string
+
+	// MaxConcurrentApplies, if greater than zero, is the number of clusters the ApplySpreader applies to
+	// concurrently, instead of DefaultMaxConcurrentApplies
+	MaxConcurrentApplies int
+}
+
+// NewMWUtil returns an MWUtil for the instance with the passed in name and namespace, using c as both the client and
+// API reader, or an error if any of the arguments are unset
+func NewMWUtil(c client.Client, ctx context.Context, log logr.Logger, //nolint:golint,revive
+	instName, instNamespace string,
+) (*MWUtil, error) {
+	if c == nil {
+		return nil, fmt.Errorf("MWUtil requires a client")
+	}
+
+	if ctx == nil {
+		return nil, fmt.Errorf("MWUtil requires a context")
+	}
+
+	if instName == "" || instNamespace == "" {
+		return nil, fmt.Errorf("MWUtil requires an instance name and namespace, got %q/%q", instNamespace, instName)
+	}
+
+	return &MWUtil{
+		Client:          c,
+		APIReader:       c,
+		Ctx:             ctx,
+		Log:             log,
+		InstName:        instName,
+		TargetNamespace: instNamespace,
+	}, nil
+}
+
+// Preflight returns an error wrapping ErrOCMNotInstalled if the hub does not serve the ManifestWork API, as when
+// the OCM work CRDs are not installed, to fail at startup rather than on the first ManifestWork apply. The client's
+// RESTMapper is used if it has one, else a ManifestWork list request.
+func (mwu *MWUtil) Preflight() error {
+	gk := schema.GroupKind{Group: ocmworkv1.GroupName, Kind: "ManifestWork"}
+
+	var err error
+
+	if mapper := mwu.restMapper(); mapper != nil {
+		_, err = mapper.RESTMapping(gk, ocmworkv1.GroupVersion.Version)
+	} else {
+		err = mwu.Client.List(mwu.Ctx, &ocmworkv1.ManifestWorkList{}, client.Limit(1))
+	}
+
+	switch {
+	case err == nil:
+		return nil
+	case meta.IsNoMatchError(err), errors.IsNotFound(err):
+		return fmt.Errorf("%s %s: %w", gk, ocmworkv1.GroupVersion, ErrOCMNotInstalled)
+	default:
+		return fmt.Errorf("failed to verify the %s API is available: %w", gk, err)
+	}
+}
+
+// restMapper returns the RESTMapper of the client, or nil if it has none
+func (mwu *MWUtil) restMapper() meta.RESTMapper {
+	if mapperClient, ok := mwu.Client.(interface{ RESTMapper() meta.RESTMapper }); ok {
+		return mapperClient.RESTMapper()
+	}
+
+	return nil
+}
+
+// IsClusterPaused returns true if the DRCluster for the cluster carries the ManifestWorksPausedAnnotation, in which
+// case MWUtil does not create, update or delete the ManifestWorks for the cluster. A cluster without a DRCluster is
+// not paused.
+func (mwu *MWUtil) IsClusterPaused(cluster string) (bool, error) {
+	drCluster := &rmn.DRCluster{}
+
+	err := mwu.Client.Get(mwu.Ctx, types.NamespacedName{Name: cluster}, drCluster)
+	if err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to get DRCluster %s: %w", cluster, err)
+	}
+
+	return drCluster.GetAnnotations()[ManifestWorksPausedAnnotation] == ManifestWorksPausedValue, nil
+}
+
+// checkClusterDeleteNotPaused returns ErrClusterReconciliationPaused for a ManifestWork delete for a paused cluster,
+// unless Finalizing is set
+func (mwu *MWUtil) checkClusterDeleteNotPaused(cluster string) error {
+	if mwu.Finalizing {
+		return nil
+	}
+
+	return mwu.checkClusterNotPaused(cluster)
+}
+
+func (mwu *MWUtil) checkClusterNotPaused(cluster string) error {
+	paused, err := mwu.IsClusterPaused(cluster)
+	if err != nil {
+		return err
+	}
+
+	if paused {
+		mwu.Log.Info("Skipping ManifestWork change for paused cluster", "cluster", cluster)
+
+		return fmt.Errorf("cluster %s: %w", cluster, ErrClusterReconciliationPaused)
+	}
+
+	return nil
+}
+
+// checkClusterNamespaceExists returns ErrClusterNamespaceMissing if the hub namespace of the ManifestWorks for
+// cluster does not exist, so that callers requeue for the cluster to register rather than fail the create
+func (mwu *MWUtil) checkClusterNamespaceExists(cluster string) error {
+	var reader client.Reader = mwu.Client
+	if mwu.APIReader != nil {
+		reader = mwu.APIReader
+	}
+
+	err := reader.Get(mwu.Ctx, types.NamespacedName{Name: ClusterManifestWorkNamespace(cluster)}, &corev1.Namespace{})
+	if err == nil {
+		return nil
+	}
+
+	if errors.IsNotFound(err) {
+		mwu.Log.Info("ManifestWork namespace missing, cluster not yet registered", "cluster", cluster)
+
+		return fmt.Errorf("cluster %s: %w", cluster, ErrClusterNamespaceMissing)
+	}
+
+	return fmt.Errorf("failed to get ManifestWork namespace for cluster %s (%w)", cluster, err)
+}
+
+// ClusterManifestWorkNamespace returns the hub namespace of the ManifestWorks for a managed cluster, which OCM
+// requires to be the namespace named for the cluster. MWUtil methods take a cluster, and map it to the namespace
+// using this alone. metav1.NamespaceAll maps to itself, to list the ManifestWorks for all clusters.
+func ClusterManifestWorkNamespace(cluster string) string {
+	return cluster
+}
+
+// ManifestWorkCluster returns the managed cluster a ManifestWork is for, the inverse of ClusterManifestWorkNamespace
+func ManifestWorkCluster(mw *ocmworkv1.ManifestWork) string {
+	return mw.GetNamespace()
+}
+
+// ManifestWorkSummary returns a compact one line description of a ManifestWork for logging, in place of the whole
+// object: its cluster and name, type, manifest count, state and owning DRPC, if any
+func ManifestWorkSummary(mw *ocmworkv1.ManifestWork) string {
+	drpc := ""
+	if name := mw.GetAnnotations()[DRPCNameAnnotation]; name != "" {
+		drpc = mw.GetAnnotations()[DRPCNamespaceAnnotation] + "/" + name
+	}
+
+	return fmt.Sprintf("%s/%s type=%q manifests=%d state=%s drpc=%q",
+		ManifestWorkCluster(mw), mw.GetName(), manifestWorkTypeFromName(mw.GetName()),
+		len(mw.Spec.Workload.Manifests), manifestWorkState(mw), drpc)
 }
 
 func ManifestWorkName(name, namespace, mwType string) string {
@@ -78,12 +497,20 @@ func (mwu *MWUtil) FindManifestWork(mwName, managedCluster string) (*ocmworkv1.M
 
 	mw := &ocmworkv1.ManifestWork{}
 
-	err := mwu.Client.Get(mwu.Ctx, types.NamespacedName{Name: mwName, Namespace: managedCluster}, mw)
+	err := mwu.Client.Get(mwu.Ctx,
+		types.NamespacedName{Name: mwName, Namespace: ClusterManifestWorkNamespace(managedCluster)}, mw)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return nil, fmt.Errorf("%w", err)
 		}
 
+		if isClusterUnreachableCause(err) {
+			return nil, &clusterUnreachableError{
+				msg: fmt.Sprintf("failed to retrieve manifestwork %s/%s", managedCluster, mwName),
+				err: err,
+			}
+		}
+
 		return nil, fmt.Errorf("failed to retrieve manifestwork (%w)", err)
 	}
 
@@ -91,512 +518,2946 @@ func (mwu *MWUtil) FindManifestWork(mwName, managedCluster string) (*ocmworkv1.M
 }
 
 func IsManifestInAppliedState(mw *ocmworkv1.ManifestWork) bool {
-	applied := false
-	available := false
-	degraded := false
-	conditions := mw.Status.Conditions
-
-	if len(conditions) > 0 {
-		for _, condition := range conditions {
-			if condition.Status == metav1.ConditionTrue {
-				switch {
-				case condition.Type == ocmworkv1.WorkApplied:
-					applied = true
-				case condition.Type == ocmworkv1.WorkAvailable:
-					available = true
-				case condition.Type == ocmworkv1.WorkDegraded:
-					degraded = true
-				}
-			}
-		}
-	}
+	applied := isManifestWorkConditionTrue(mw, ocmworkv1.WorkApplied)
+	available := isManifestWorkConditionTrue(mw, ocmworkv1.WorkAvailable)
+	degraded := isManifestWorkConditionTrue(mw, ocmworkv1.WorkDegraded)
 
 	return applied && available && !degraded
 }
 
-func (mwu *MWUtil) CreateOrUpdateVRGManifestWork(
-	name, namespace, homeCluster string,
-	vrg rmn.VolumeReplicationGroup, annotations map[string]string,
-) error {
-	mwu.Log.Info(fmt.Sprintf("Create or Update manifestwork %s:%s:%s:%+v",
-		name, namespace, homeCluster, vrg))
+// ManifestWorkObserved returns true if the status conditions of the ManifestWork reflect its current generation, and
+// hence its status is not stale with respect to its latest spec. A ManifestWork without status conditions has not
+// been observed yet.
+func (mwu *MWUtil) ManifestWorkObserved(mw *ocmworkv1.ManifestWork) bool {
+	return manifestWorkObserved(mw)
+}
 
-	manifestWork, err := mwu.generateVRGManifestWork(name, namespace, homeCluster, vrg, annotations)
-	if err != nil {
-		return err
+func manifestWorkObserved(mw *ocmworkv1.ManifestWork) bool {
+	if len(mw.Status.Conditions) == 0 {
+		return false
 	}
 
-	return mwu.createOrUpdateManifestWork(manifestWork, homeCluster)
+	for i := range mw.Status.Conditions {
+		if mw.Status.Conditions[i].ObservedGeneration != mw.GetGeneration() {
+			return false
+		}
+	}
+
+	return true
 }
 
-func (mwu *MWUtil) generateVRGManifestWork(name, namespace, homeCluster string,
-	vrg rmn.VolumeReplicationGroup, annotations map[string]string,
-) (*ocmworkv1.ManifestWork, error) {
-	vrgClientManifest, err := mwu.generateVRGManifest(vrg)
-	if err != nil {
-		mwu.Log.Error(err, "failed to generate VolumeReplicationGroup manifest")
+// IsManifestWorkObservedAndApplied returns true if the ManifestWork is in applied state as per a status that reflects
+// its current generation
+func (mwu *MWUtil) IsManifestWorkObservedAndApplied(mw *ocmworkv1.ManifestWork) bool {
+	return mwu.ManifestWorkObserved(mw) && IsManifestInAppliedState(mw)
+}
 
-		return nil, err
+// ManifestWorkAge returns the time elapsed since the ManifestWork was created
+func (mwu *MWUtil) ManifestWorkAge(mw *ocmworkv1.ManifestWork) time.Duration {
+	return time.Since(mw.GetCreationTimestamp().Time)
+}
+
+// ManifestWorkNotAppliedDuration returns how long the ManifestWork has not been applied, which is zero if its
+// WorkApplied condition is true, the time since the condition last transitioned if it is not true, or the time since
+// the ManifestWork was created if the work agent has not reported the condition yet
+func (mwu *MWUtil) ManifestWorkNotAppliedDuration(mw *ocmworkv1.ManifestWork) time.Duration {
+	condition := FindManifestWorkCondition(mw, ocmworkv1.WorkApplied)
+	if condition == nil {
+		return mwu.ManifestWorkAge(mw)
 	}
 
-	manifests := []ocmworkv1.Manifest{*vrgClientManifest}
+	if condition.Status == metav1.ConditionTrue {
+		return 0
+	}
 
-	return mwu.newManifestWork(
-		fmt.Sprintf(ManifestWorkNameFormat, name, namespace, MWTypeVRG),
-		homeCluster,
-		map[string]string{},
-		manifests, annotations), nil
+	return time.Since(condition.LastTransitionTime.Time)
 }
 
-func (mwu *MWUtil) generateVRGManifest(vrg rmn.VolumeReplicationGroup) (*ocmworkv1.Manifest, error) {
-	return mwu.GenerateManifest(vrg)
+// ManifestWorkApplyOverdue returns true if the ManifestWork has not been applied for longer than threshold, which
+// indicates a slow or unresponsive work agent on the cluster, for callers to escalate
+func (mwu *MWUtil) ManifestWorkApplyOverdue(mw *ocmworkv1.ManifestWork, threshold time.Duration) bool {
+	return mwu.ManifestWorkNotAppliedDuration(mw) > threshold
 }
 
-// MaintenanceMode ManifestWork creation
-func (mwu *MWUtil) CreateOrUpdateMModeManifestWork(
-	name, cluster string,
-	mMode rmn.MaintenanceMode, annotations map[string]string,
-) error {
-	mwu.Log.Info(fmt.Sprintf("Create or Update manifestwork %s:%s:%+v", name, cluster, mMode))
+// FindManifestWorkCondition returns the ManifestWork status condition of the passed in type, or nil if absent
+func FindManifestWorkCondition(mw *ocmworkv1.ManifestWork, condType string) *metav1.Condition {
+	return meta.FindStatusCondition(mw.Status.Conditions, condType)
+}
 
-	manifestWork, err := mwu.generateMModeManifestWork(name, cluster, mMode, annotations)
-	if err != nil {
-		return err
+// ManifestWorkAppliedProgress returns the number of manifests in the ManifestWork that are reported as applied in
+// its per resource status, and the total number of manifests in the ManifestWork
+func (mwu *MWUtil) ManifestWorkAppliedProgress(mw *ocmworkv1.ManifestWork) (int, int) {
+	applied := 0
+	total := len(mw.Spec.Workload.Manifests)
+
+	for _, manifest := range mw.Status.ResourceStatus.Manifests {
+		condition := meta.FindStatusCondition(manifest.Conditions, string(ocmworkv1.ManifestApplied))
+		if condition != nil && condition.Status == metav1.ConditionTrue {
+			applied++
+		}
 	}
 
-	return mwu.createOrUpdateManifestWork(manifestWork, cluster)
+	return applied, total
 }
 
-func (mwu *MWUtil) generateMModeManifestWork(name, cluster string,
-	mMode rmn.MaintenanceMode, annotations map[string]string,
-) (*ocmworkv1.ManifestWork, error) {
-	mModeManifest, err := mwu.generateMModeManifest(mMode)
-	if err != nil {
-		mwu.Log.Error(err, "failed to generate MaintenanceMode manifest")
+func isManifestWorkConditionTrue(mw *ocmworkv1.ManifestWork, condType string) bool {
+	condition := FindManifestWorkCondition(mw, condType)
 
-		return nil, err
-	}
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
 
-	manifests := []ocmworkv1.Manifest{*mModeManifest}
+// CreateOrUpdateVRGManifestWork creates or updates the VRG ManifestWork on homeCluster. When forceResync is set,
+// the VRG is stamped with a new ForceResyncAnnotation value to trigger an immediate resync on the managed cluster,
+// otherwise any value stamped previously is carried forward unchanged. The replication mode specific fields of
+// the VRG may be set using opts, and are validated before the ManifestWork is created or updated.
+func (mwu *MWUtil) CreateOrUpdateVRGManifestWork(
+	name, namespace, homeCluster string,
+	vrg rmn.VolumeReplicationGroup, annotations map[string]string,
+	forceResync bool, opts ...VRGOption,
+) error {
+	_, err := mwu.EnsureVRGManifestWork(name, namespace, homeCluster, vrg, annotations, forceResync, opts...)
 
-	return mwu.newManifestWork(
-		fmt.Sprintf(ManifestWorkNameFormatClusterScope, name, MWTypeMMode),
-		cluster,
-		map[string]string{
-			MModesLabel: "",
-		},
-		manifests, annotations), nil
+	return err
 }
 
-func (mwu *MWUtil) generateMModeManifest(mMode rmn.MaintenanceMode) (*ocmworkv1.Manifest, error) {
-	return mwu.GenerateManifest(mMode)
+// ManifestWorkState is the state of a ManifestWork as reported by the work agent
+type ManifestWorkState string
+
+const (
+	// ManifestWorkStatePending is the state of a ManifestWork not yet applied in its current generation
+	ManifestWorkStatePending = ManifestWorkState("Pending")
+
+	// ManifestWorkStateApplied is the state of a ManifestWork applied and available in its current generation
+	ManifestWorkStateApplied = ManifestWorkState("Applied")
+
+	// ManifestWorkStateDegraded is the state of a ManifestWork that the work agent reports as degraded
+	ManifestWorkStateDegraded = ManifestWorkState("Degraded")
+)
+
+// ManifestWorkStateOf returns the state of the ManifestWork per its status
+func (mwu *MWUtil) ManifestWorkStateOf(mw *ocmworkv1.ManifestWork) ManifestWorkState {
+	return manifestWorkState(mw)
 }
 
-func (mwu *MWUtil) ListMModeManifests(cluster string) (*ocmworkv1.ManifestWorkList, error) {
-	matchLabels := map[string]string{
-		MModesLabel: "",
+func manifestWorkState(mw *ocmworkv1.ManifestWork) ManifestWorkState {
+	switch {
+	case isManifestWorkConditionTrue(mw, ocmworkv1.WorkDegraded):
+		return ManifestWorkStateDegraded
+	case manifestWorkObserved(mw) && IsManifestInAppliedState(mw):
+		return ManifestWorkStateApplied
+	default:
+		return ManifestWorkStatePending
 	}
-	listOptions := []client.ListOption{
-		client.InNamespace(cluster),
-		client.MatchingLabels(matchLabels),
+}
+
+// EnsureVRGManifestWork creates or updates the VRG ManifestWork as CreateOrUpdateVRGManifestWork does, and returns
+// its state as of the create or update, sparing a FindManifestWork to read it. A ManifestWork that is created or
+// updated is hence Pending. A VRG for the local cluster is Applied once created or updated.
+func (mwu *MWUtil) EnsureVRGManifestWork(
+	name, namespace, homeCluster string,
+	vrg rmn.VolumeReplicationGroup, annotations map[string]string,
+	forceResync bool, opts ...VRGOption,
+) (ManifestWorkState, error) {
+	if err := applyVRGOptions(&vrg, opts...); err != nil {
+		return "", err
 	}
 
-	mModeMWs := &ocmworkv1.ManifestWorkList{}
-	err := mwu.APIReader.List(context.TODO(), mModeMWs, listOptions...)
+	sourceGeneration := mwu.vrgSourceGeneration(vrg)
 
-	return mModeMWs, err
-}
+	// The existing VRG ManifestWork is read once, for the annotations carried forward as well as the update
+	existingMW, err := mwu.findVRGManifestWork(name, namespace, homeCluster)
+	if err != nil {
+		return "", err
+	}
 
-func ExtractMModeFromManifestWork(mw *ocmworkv1.ManifestWork) (*rmn.MaintenanceMode, error) {
-	gvk := schema.GroupVersionKind{
-		Group:   rmn.GroupVersion.Group,
-		Version: rmn.GroupVersion.Version,
-		Kind:    "MaintenanceMode",
+	if mwu.vrgManifestWorkAtSourceGeneration(existingMW, sourceGeneration, forceResync) {
+		manifestWorkReconcileCountIncrement(MWActionSkip, existingMW.Name)
+
+		return mwu.ManifestWorkStateOf(existingMW), nil
 	}
 
-	rawObject, err := GetRawExtension(mw.Spec.Workload.Manifests, gvk)
+	mwu.Log.Info("Create or Update VRG ManifestWork", "name", name, "namespace", namespace, "cluster", homeCluster)
+
+	existingVRG, err := mwu.findExistingVRG(name, namespace, homeCluster, existingMW)
 	if err != nil {
-		return nil, fmt.Errorf("failed fetching MaintenanceMode from manifest %w", err)
+		return "", err
 	}
 
-	if rawObject == nil {
-		return nil, nil
+	setVRGForceResyncAnnotation(&vrg, existingVRG, forceResync)
+
+	if mwu.isLocalCluster(homeCluster) {
+		if err := mwu.createOrUpdateLocalVRG(vrg); err != nil {
+			return "", err
+		}
+
+		return ManifestWorkStateApplied, nil
 	}
 
-	mMode := &rmn.MaintenanceMode{}
+	manifestWork, err := mwu.generateVRGManifestWork(name, namespace, homeCluster, vrg, annotations)
+	if err != nil {
+		return "", err
+	}
 
-	err = json.Unmarshal(rawObject.Raw, mMode)
+	if sourceGeneration != "" {
+		manifestWork.Annotations[SourceGenerationAnnotation] = sourceGeneration
+	}
+
+	mw, err := mwu.createOrUpdateManifestWorkFrom(manifestWork, homeCluster,
+		func() (*ocmworkv1.ManifestWork, error) { return existingMW, nil })
 	if err != nil {
-		return nil, fmt.Errorf("failed unmarshaling MaintenanceMode from manifest %w", err)
+		return "", err
 	}
 
-	return mMode, nil
+	return mwu.ManifestWorkStateOf(mw), nil
 }
 
-// NetworkFence MW creation
-func (mwu *MWUtil) CreateOrUpdateNFManifestWork(
-	name, namespace, homeCluster string,
-	nf csiaddonsv1alpha1.NetworkFence, annotations map[string]string,
-) error {
-	mwu.Log.Info(fmt.Sprintf("Create or Update manifestwork %s:%s:%s:%+v",
-		name, namespace, homeCluster, nf))
-
-	manifestWork, err := mwu.generateNFManifestWork(name, namespace, homeCluster, nf, annotations)
-	if err != nil {
-		return err
+// vrgSourceGeneration returns the SourceGenerationAnnotation value for vrg, or an empty string if SourceGeneration is
+// not set. The replication state is included, as it is passed in separately from the other inputs.
+func (mwu *MWUtil) vrgSourceGeneration(vrg rmn.VolumeReplicationGroup) string {
+	if mwu.SourceGeneration == "" {
+		return ""
 	}
 
-	return mwu.createOrUpdateManifestWork(manifestWork, homeCluster)
+	return mwu.SourceGeneration + "/" + string(vrg.Spec.ReplicationState)
 }
 
-func (mwu *MWUtil) generateNFManifestWork(name, namespace, homeCluster string,
-	nf csiaddonsv1alpha1.NetworkFence, annotations map[string]string,
-) (*ocmworkv1.ManifestWork, error) {
-	nfClientManifest, err := mwu.generateNFManifest(nf)
+// findVRGManifestWork returns the VRG ManifestWork for homeCluster, or nil if it does not exist or the VRG is
+// created directly on the local cluster
+func (mwu *MWUtil) findVRGManifestWork(name, namespace, homeCluster string) (*ocmworkv1.ManifestWork, error) {
+	if mwu.isLocalCluster(homeCluster) {
+		return nil, nil
+	}
+
+	mw, err := mwu.FindManifestWork(ManifestWorkName(name, namespace, MWTypeVRG), homeCluster)
 	if err != nil {
-		mwu.Log.Error(err, "failed to generate NetworkFence manifest")
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
 
 		return nil, err
 	}
 
-	manifests := []ocmworkv1.Manifest{*nfClientManifest}
+	return mw, nil
+}
 
-	// manifest work name for NetworkFence resource is
-	// "name-type-mw"
-	// name: name of the resource received from higher layer
-	//       that wants to create the csiaddonsv1alpha1.NetworkFence resource
-	// type: type of the resource for this ManifestWork
-	return mwu.newManifestWork(
-		fmt.Sprintf(ManifestWorkNameFormat, name, namespace, MWTypeNF),
-		homeCluster,
-		map[string]string{"app": "NF"},
-		manifests, annotations), nil
+// vrgManifestWorkAtSourceGeneration returns true if the existing VRG ManifestWork mw was last created or updated for
+// sourceGeneration, and is otherwise as Ramen would leave it, or false if the VRG ManifestWork is to be regenerated.
+// The manifests of mw are not decoded or compared.
+func (mwu *MWUtil) vrgManifestWorkAtSourceGeneration(mw *ocmworkv1.ManifestWork, sourceGeneration string,
+	forceResync bool,
+) bool {
+	if mw == nil || sourceGeneration == "" || forceResync {
+		return false
+	}
+
+	return mw.GetDeletionTimestamp().IsZero() &&
+		IsManifestWorkLabeledManagedByRamen(mw) &&
+		(!mwu.ProtectionFinalizer || controllerutil.ContainsFinalizer(mw, ManifestWorkProtectionFinalizer)) &&
+		mw.GetAnnotations()[SourceGenerationAnnotation] == sourceGeneration
 }
 
-func (mwu *MWUtil) generateNFManifest(nf csiaddonsv1alpha1.NetworkFence) (*ocmworkv1.Manifest, error) {
-	return mwu.GenerateManifest(nf)
+// SetVRGActionInManifestWork sets only the replicationState of the VRG in the existing VRG ManifestWork, to either
+// primary or secondary, preserving the rest of the VRG manifest as is.
+func (mwu *MWUtil) SetVRGActionInManifestWork(name, namespace, cluster, action string) error {
+	state := rmn.ReplicationState(action)
+	if state != rmn.Primary && state != rmn.Secondary {
+		return fmt.Errorf("invalid VRG replication state %q", action)
+	}
+
+	mwName := ManifestWorkName(name, namespace, MWTypeVRG)
+
+	mw, err := mwu.FindManifestWork(mwName, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get ManifestWork %s/%s: %w", cluster, mwName, err)
+	}
+
+	return mwu.setVRGStateInManifestWork(mw, cluster, state)
 }
 
-func (mwu *MWUtil) CreateOrUpdateNamespaceManifest(
-	name string, namespaceName string, managedClusterNamespace string,
-	annotations map[string]string,
+// ReapplyVRGManifestWork forces an update of the existing VRG ManifestWork, and hence a re-apply of the VRG on the
+// cluster, by stamping the VRG with a new ReapplyAnnotation value. This is useful when the VRG on the cluster has
+// drifted from the ManifestWork while the desired VRG itself is unchanged. The annotation value is carried forward
+// by EnsureVRGManifestWork, hence subsequent reconciles do not update the ManifestWork again.
+func (mwu *MWUtil) ReapplyVRGManifestWork(name, namespace, cluster string) error {
+	mwName := ManifestWorkName(name, namespace, MWTypeVRG)
+
+	mw, err := mwu.FindManifestWork(mwName, cluster)
+	if err != nil {
+		return fmt.Errorf("failed to get ManifestWork %s/%s: %w", cluster, mwName, err)
+	}
+
+	value := time.Now().UTC().Format(time.RFC3339Nano)
+
+	mwu.Log.Info("Reapplying VRG ManifestWork", "MW", ManifestWorkSummary(mw), "value", value)
+
+	return mwu.updateVRGManifestInManifestWork(mw, cluster, func(vrg *unstructured.Unstructured) error {
+		if err := unstructured.SetNestedField(vrg.Object, value,
+			"metadata", "annotations", ReapplyAnnotation); err != nil {
+			return fmt.Errorf("failed to set %s in VRG manifest: %w", ReapplyAnnotation, err)
+		}
+
+		return nil
+	})
+}
+
+func (mwu *MWUtil) setVRGStateInManifestWork(mw *ocmworkv1.ManifestWork, cluster string,
+	state rmn.ReplicationState,
 ) error {
-	manifest, err := mwu.GenerateManifest(Namespace(namespaceName))
+	return mwu.updateVRGManifestInManifestWork(mw, cluster, func(vrg *unstructured.Unstructured) error {
+		if err := unstructured.SetNestedField(vrg.Object, string(state), "spec", "replicationState"); err != nil {
+			return fmt.Errorf("failed to set replicationState in VRG manifest: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// updateVRGManifestInManifestWork updates the VRG ManifestWork with its VRG manifest modified by mutate, preserving
+// the remaining manifests as is
+func (mwu *MWUtil) updateVRGManifestInManifestWork(mw *ocmworkv1.ManifestWork, cluster string,
+	mutate func(*unstructured.Unstructured) error,
+) error {
+	manifests := make([]ocmworkv1.Manifest, len(mw.Spec.Workload.Manifests))
+	copy(manifests, mw.Spec.Workload.Manifests)
+
+	index, vrg, err := findVRGManifest(manifests)
 	if err != nil {
+		return fmt.Errorf("ManifestWork %s/%s: %w", cluster, mw.GetName(), err)
+	}
+
+	if err := mutate(vrg); err != nil {
 		return err
 	}
 
-	manifests := []ocmworkv1.Manifest{
-		*manifest,
+	manifest, err := mwu.generateManifestFromUnstructured(vrg)
+	if err != nil {
+		return err
 	}
 
-	mwName := fmt.Sprintf(ManifestWorkNameFormat, name, namespaceName, MWTypeNS)
-	manifestWork := mwu.newManifestWork(
-		mwName,
-		managedClusterNamespace,
-		map[string]string{
-			OCMBackupLabelKey: OCMBackupLabelValue,
-		},
-		manifests,
-		annotations)
+	manifests[index] = *manifest
 
-	return mwu.createOrUpdateManifestWork(manifestWork, managedClusterNamespace)
+	labels := make(map[string]string, len(mw.GetLabels()))
+	UpdateStringMap(&labels, mw.GetLabels())
+
+	// The VRG no longer is as generated for the source generation stamped, hence the stamp is dropped for
+	// EnsureVRGManifestWork to generate the VRG again
+	annotations := make(map[string]string, len(mw.GetAnnotations()))
+	UpdateStringMap(&annotations, mw.GetAnnotations())
+	delete(annotations, SourceGenerationAnnotation)
+
+	return mwu.createOrUpdateManifestWork(
+		mwu.newManifestWork(mw.GetName(), cluster, labels, manifests, annotations), cluster)
 }
 
-func Namespace(name string) *corev1.Namespace {
-	return &corev1.Namespace{
-		TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
-		ObjectMeta: metav1.ObjectMeta{Name: name},
+// DrainProgress reports the progress of DrainCluster
+type DrainProgress struct {
+	// Total number of VRG ManifestWorks on the cluster when the drain was attempted
+	Total int
+
+	// Demoted is the number of VRG ManifestWorks whose Secondary VRG is applied on the cluster
+	Demoted int
+
+	// Removed is the number of demoted VRG ManifestWorks deleted by the drain attempt
+	Removed int
+
+	// Pending lists the VRG ManifestWorks still waiting for their Secondary VRG to be applied
+	Pending []string
+}
+
+// DrainCluster demotes the VRGs in all Ramen managed VRG ManifestWorks on the cluster to Secondary, and once a
+// ManifestWork's status reports its current generation, with the Secondary VRG, as applied, deletes it if remove is
+// set. Demotion is confirmed as per the ManifestWork status only, callers requiring the VRG status itself should
+// inspect the VRGs on the cluster. Until all VRGs are demoted ErrClusterDrainPending is returned along with the
+// progress made, for the caller to requeue. A drain is resumed by calling DrainCluster again.
+func (mwu *MWUtil) DrainCluster(cluster string, remove bool) (DrainProgress, error) {
+	progress := DrainProgress{Pending: []string{}}
+
+	mws, err := mwu.listManifestWorks(cluster, ManagedByRamenSelector(), func(mw *ocmworkv1.ManifestWork) bool {
+		_, _, err := findVRGManifest(mw.Spec.Workload.Manifests)
+
+		return err == nil
+	})
+	if err != nil {
+		return progress, err
+	}
+
+	progress.Total = len(mws)
+
+	for i := range mws {
+		mw := &mws[i]
+
+		_, vrg, err := findVRGManifest(mw.Spec.Workload.Manifests)
+		if err != nil {
+			return progress, err
+		}
+
+		state, _, _ := unstructured.NestedString(vrg.Object, "spec", "replicationState")
+		if rmn.ReplicationState(state) != rmn.Secondary {
+			if err := mwu.setVRGStateInManifestWork(mw, cluster, rmn.Secondary); err != nil {
+				return progress, fmt.Errorf("failed to demote VRG in ManifestWork %s/%s: %w", cluster, mw.GetName(), err)
+			}
+
+			progress.Pending = append(progress.Pending, mw.GetName())
+
+			continue
+		}
+
+		if !mwu.IsManifestWorkObservedAndApplied(mw) {
+			progress.Pending = append(progress.Pending, mw.GetName())
+
+			continue
+		}
+
+		progress.Demoted++
+
+		if !remove {
+			continue
+		}
+
+		if err := mwu.DeleteManifestWork(mw.GetName(), cluster); err != nil {
+			return progress, err
+		}
+
+		progress.Removed++
+	}
+
+	if len(progress.Pending) != 0 {
+		return progress, fmt.Errorf("cluster %s, %d of %d VRGs not yet demoted: %w", cluster, len(progress.Pending),
+			progress.Total, ErrClusterDrainPending)
 	}
+
+	mwu.Log.Info("Drained cluster", "cluster", cluster, "demoted", progress.Demoted, "removed", progress.Removed)
+
+	return progress, nil
 }
 
-func GetRawExtension(
-	manifests []ocmworkv1.Manifest,
-	gvk schema.GroupVersionKind,
-) (*runtime.RawExtension, error) {
-	for _, manifest := range manifests {
+// DetectDuplicateVRGManifestWorks returns the names of the VRG ManifestWorks in the cluster namespace that are
+// annotated as created for the DRPC name/namespace, if there is more than one of them, as is the case when a
+// ManifestWork exists under both an old and a new name format. Nil is returned if there are no duplicates.
+func (mwu *MWUtil) DetectDuplicateVRGManifestWorks(name, namespace, cluster string) ([]string, error) {
+	mws, err := mwu.listManifestWorks(cluster, nil, func(mw *ocmworkv1.ManifestWork) bool {
+		if mw.GetAnnotations()[DRPCNameAnnotation] != name ||
+			mw.GetAnnotations()[DRPCNamespaceAnnotation] != namespace {
+			return false
+		}
+
+		_, _, err := findVRGManifest(mw.Spec.Workload.Manifests)
+
+		return err == nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mws) <= 1 {
+		return nil, nil
+	}
+
+	mwNames := make([]string, 0, len(mws))
+	for i := range mws {
+		mwNames = append(mwNames, mws[i].GetName())
+	}
+
+	mwu.Log.Info("Detected duplicate VRG ManifestWorks", "cluster", cluster, "names", mwNames)
+
+	return mwNames, nil
+}
+
+// ClustersWithManifestWorksForDRPC returns the sorted, distinct cluster namespaces that have ManifestWorks annotated as
+// created for the DRPC name/namespace, independent of the clusters currently in its DRPolicy
+func (mwu *MWUtil) ClustersWithManifestWorksForDRPC(name, namespace string) ([]string, error) {
+	mws, err := mwu.listManifestWorks(metav1.NamespaceAll, nil, func(mw *ocmworkv1.ManifestWork) bool {
+		return mw.GetAnnotations()[DRPCNameAnnotation] == name &&
+			mw.GetAnnotations()[DRPCNamespaceAnnotation] == namespace
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	clusters := sets.NewString()
+	for i := range mws {
+		clusters.Insert(ManifestWorkCluster(&mws[i]))
+	}
+
+	return clusters.List(), nil
+}
+
+// FindOrphanedManifestWorks returns the Ramen managed ManifestWorks on cluster annotated as created for a DRPC that
+// is not in existingDRPCs, keyed by DRPC namespace/name, such as those left behind by a DRPC deleted while the cluster
+// was unreachable, for a garbage collector to delete. The DRPC of each is available from its DRPCNameAnnotation and
+// DRPCNamespaceAnnotation. ManifestWorks not created for a DRPC, such as the DRCluster ManifestWork, are ignored.
+func (mwu *MWUtil) FindOrphanedManifestWorks(existingDRPCs map[string]bool, cluster string,
+) ([]ocmworkv1.ManifestWork, error) {
+	mws, err := mwu.listManifestWorks(cluster, ManagedByRamenSelector(), func(mw *ocmworkv1.ManifestWork) bool {
+		name, namespace := mw.GetAnnotations()[DRPCNameAnnotation], mw.GetAnnotations()[DRPCNamespaceAnnotation]
+		if name == "" || namespace == "" {
+			return false
+		}
+
+		return !existingDRPCs[types.NamespacedName{Namespace: namespace, Name: name}.String()]
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range mws {
+		mwu.Log.Info("Found orphaned ManifestWork", "MW", ManifestWorkSummary(&mws[i]),
+			"drpcNamespace", mws[i].GetAnnotations()[DRPCNamespaceAnnotation])
+	}
+
+	return mws, nil
+}
+
+// BackfillDRPCAnnotations adds the DRPCNameAnnotation and DRPCNamespaceAnnotation to the VRG and namespace
+// ManifestWorks of the DRPC name/namespace on cluster that lack them, as created by older Ramen releases, so that
+// these are found by the annotation based listing of ManifestWorks for a DRPC. Existing values are left unchanged.
+func (mwu *MWUtil) BackfillDRPCAnnotations(cluster, name, namespace string) error {
+	if err := mwu.checkClusterNotPaused(cluster); err != nil {
+		return err
+	}
+
+	for _, mwType := range []string{MWTypeVRG, MWTypeNS} {
+		mwName := ManifestWorkName(name, namespace, mwType)
+
+		err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+			mw, err := mwu.FindManifestWork(mwName, cluster)
+			if err != nil {
+				return err
+			}
+
+			annotations := mw.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+
+			_, hasName := annotations[DRPCNameAnnotation]
+			_, hasNamespace := annotations[DRPCNamespaceAnnotation]
+
+			if hasName && hasNamespace {
+				return nil
+			}
+
+			if !hasName {
+				annotations[DRPCNameAnnotation] = name
+			}
+
+			if !hasNamespace {
+				annotations[DRPCNamespaceAnnotation] = namespace
+			}
+
+			mw.SetAnnotations(annotations)
+
+			mwu.Log.Info("Backfilling DRPC annotations on ManifestWork", "cluster", cluster, "name", mwName)
+
+			return mwu.Client.Update(mwu.Ctx, mw)
+		})
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to backfill DRPC annotations on ManifestWork %s/%s (%w)", cluster, mwName, err)
+		}
+	}
+
+	return nil
+}
+
+// UpdateDRPCAnnotations sets the DRPCNameAnnotation and DRPCNamespaceAnnotation of the ManifestWork mwName on cluster
+// to newName and newNamespace, for a DRPC recreated with a new identity, leaving the ManifestWork spec unchanged so
+// that it is not re-applied on the cluster.
+func (mwu *MWUtil) UpdateDRPCAnnotations(mwName, cluster, newName, newNamespace string) error {
+	if err := mwu.checkClusterNotPaused(cluster); err != nil {
+		return err
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		mw, err := mwu.FindManifestWork(mwName, cluster)
+		if err != nil {
+			return err
+		}
+
+		annotations := mw.GetAnnotations()
+		if annotations[DRPCNameAnnotation] == newName && annotations[DRPCNamespaceAnnotation] == newNamespace {
+			return nil
+		}
+
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		mwu.Log.Info("Updating DRPC annotations on ManifestWork", "cluster", cluster, "name", mwName,
+			"from", fmt.Sprintf("%s/%s", annotations[DRPCNamespaceAnnotation], annotations[DRPCNameAnnotation]),
+			"to", fmt.Sprintf("%s/%s", newNamespace, newName))
+
+		annotations[DRPCNameAnnotation] = newName
+		annotations[DRPCNamespaceAnnotation] = newNamespace
+		mw.SetAnnotations(annotations)
+
+		return mwu.Client.Update(mwu.Ctx, mw)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update DRPC annotations on ManifestWork %s/%s (%w)", cluster, mwName, err)
+	}
+
+	return nil
+}
+
+// ManifestWorkStatusSummary is a rollup of the status of the ManifestWorks created for a DRPC
+type ManifestWorkStatusSummary struct {
+	// Total number of ManifestWorks found
+	Total int
+
+	// Applied is the number of ManifestWorks in applied state
+	Applied int
+
+	// Degraded is the number of ManifestWorks reported as degraded
+	Degraded int
+
+	// Missing is the number of clusters with no ManifestWork for the DRPC
+	Missing int
+
+	// Problems lists the degraded ManifestWorks and the clusters missing ManifestWorks
+	Problems []ManifestWorkProblem
+}
+
+// ManifestWorkProblem describes a degraded ManifestWork, or a cluster missing ManifestWorks when Name is empty
+type ManifestWorkProblem struct {
+	Cluster string
+	Name    string
+	Reason  string
+}
+
+// ListDRPCManifestWorks returns the Ramen managed ManifestWorks annotated as created for the DRPC name/namespace on
+// each of clusters, keyed by cluster and then by ManifestWork type, as derived from the ManifestWork name. Each cluster
+// namespace is listed once, filtered by the ManagedByLabel, instead of getting each ManifestWork of the DRPC. Every
+// cluster is present in the result, with an empty map if it has no ManifestWorks for the DRPC. Of multiple
+// ManifestWorks of the same type on a cluster, only the first by name is returned.
+func (mwu *MWUtil) ListDRPCManifestWorks(name, namespace string, clusters []string,
+) (map[string]map[string]*ocmworkv1.ManifestWork, error) {
+	clusterMWs := make(map[string]map[string]*ocmworkv1.ManifestWork, len(clusters))
+
+	for _, cluster := range clusters {
+		mws, err := mwu.listManifestWorks(cluster, ManagedByRamenSelector(), func(mw *ocmworkv1.ManifestWork) bool {
+			return mw.GetAnnotations()[DRPCNameAnnotation] == name &&
+				mw.GetAnnotations()[DRPCNamespaceAnnotation] == namespace
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		typeMWs := make(map[string]*ocmworkv1.ManifestWork, len(mws))
+
+		for i := range mws {
+			mwType := manifestWorkTypeFromName(mws[i].GetName())
+			if existing, ok := typeMWs[mwType]; ok {
+				mwu.Log.Info("Ignoring duplicate ManifestWork for DRPC", "cluster", cluster, "type", mwType,
+					"name", mws[i].GetName(), "kept", existing.GetName())
+
+				continue
+			}
+
+			typeMWs[mwType] = &mws[i]
+		}
+
+		clusterMWs[cluster] = typeMWs
+	}
+
+	return clusterMWs, nil
+}
+
+// AggregateDRPCManifestWorkStatus summarizes the status of the ManifestWorks annotated as created for the DRPC
+// name/namespace across clusters
+func (mwu *MWUtil) AggregateDRPCManifestWorkStatus(name, namespace string, clusters []string,
+) (ManifestWorkStatusSummary, error) {
+	summary := ManifestWorkStatusSummary{Problems: []ManifestWorkProblem{}}
+
+	clusterMWs, err := mwu.ListDRPCManifestWorks(name, namespace, clusters)
+	if err != nil {
+		return summary, err
+	}
+
+	for _, cluster := range clusters {
+		typeMWs := clusterMWs[cluster]
+		if len(typeMWs) == 0 {
+			summary.Missing++
+			summary.Problems = append(summary.Problems,
+				ManifestWorkProblem{Cluster: cluster, Reason: "no ManifestWork found"})
+
+			continue
+		}
+
+		for _, mwType := range sets.StringKeySet(typeMWs).List() {
+			mw := typeMWs[mwType]
+			summary.Total++
+
+			if IsManifestInAppliedState(mw) {
+				summary.Applied++
+			}
+
+			if condition := FindManifestWorkCondition(mw, ocmworkv1.WorkDegraded); condition != nil &&
+				condition.Status == metav1.ConditionTrue {
+				summary.Degraded++
+				summary.Problems = append(summary.Problems, ManifestWorkProblem{
+					Cluster: cluster,
+					Name:    mw.GetName(),
+					Reason:  fmt.Sprintf("%s: %s", condition.Reason, condition.Message),
+				})
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+// findVRGManifest returns the index and unstructured content of the VRG manifest in manifests
+func findVRGManifest(manifests []ocmworkv1.Manifest) (int, *unstructured.Unstructured, error) {
+	gvk := rmn.GroupVersion.WithKind("VolumeReplicationGroup")
+
+	for i := range manifests {
 		obj := &unstructured.Unstructured{}
 
-		err := json.Unmarshal(manifest.Raw, obj)
+		if err := json.Unmarshal(manifests[i].Raw, obj); err != nil {
+			return 0, nil, fmt.Errorf("failed to unmarshal JSON. Error %w", err)
+		}
+
+		if obj.GroupVersionKind() == gvk {
+			return i, obj, nil
+		}
+	}
+
+	return 0, nil, ErrVRGManifestNotFound
+}
+
+// FindPrimaryClusterForDRPC returns the cluster, among clusters, whose VRG ManifestWork for the DRPC with the passed
+// in name and VRG namespace carries a Primary VRG. It returns ErrNoPrimaryCluster if there is none, and
+// ErrMultiplePrimaryClusters if there is more than one, which would otherwise lead to a split brain.
+func (mwu *MWUtil) FindPrimaryClusterForDRPC(name, namespace string, clusters []string) (string, error) {
+	mwName := ManifestWorkName(name, namespace, MWTypeVRG)
+	primaries := []string{}
+
+	for _, cluster := range clusters {
+		mw, err := mwu.FindManifestWork(mwName, cluster)
 		if err != nil {
-			return nil, fmt.Errorf("failed to unmarshal JSON. Error %w", err)
+			if errors.IsNotFound(err) {
+				continue
+			}
+
+			return "", err
+		}
+
+		vrg, err := ExtractVRGFromManifestWork(mw)
+		if err != nil {
+			return "", fmt.Errorf("ManifestWork %s/%s: %w", cluster, mwName, err)
+		}
+
+		if vrg != nil && vrg.Spec.ReplicationState == rmn.Primary {
+			primaries = append(primaries, cluster)
+		}
+	}
+
+	switch len(primaries) {
+	case 0:
+		return "", fmt.Errorf("DRPC %s/%s: %w", namespace, name, ErrNoPrimaryCluster)
+	case 1:
+		return primaries[0], nil
+	default:
+		return "", fmt.Errorf("DRPC %s/%s clusters %v: %w", namespace, name, primaries, ErrMultiplePrimaryClusters)
+	}
+}
+
+// findExistingVRG returns the VRG currently in the existing VRG ManifestWork mw, or on the local cluster, or nil if
+// absent
+func (mwu *MWUtil) findExistingVRG(name, namespace, homeCluster string, mw *ocmworkv1.ManifestWork,
+) (*rmn.VolumeReplicationGroup, error) {
+	if mwu.isLocalCluster(homeCluster) {
+		vrg := &rmn.VolumeReplicationGroup{}
+
+		err := mwu.Client.Get(mwu.Ctx, types.NamespacedName{Name: name, Namespace: namespace}, vrg)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil, nil
+			}
+
+			return nil, err
 		}
 
-		objgvk := obj.GroupVersionKind()
-		if objgvk == gvk {
-			return &manifest.RawExtension, nil
-		}
-	}
+		return vrg, nil
+	}
+
+	if mw == nil {
+		return nil, nil
+	}
+
+	return ExtractVRGFromManifestWork(mw)
+}
+
+func (mwu *MWUtil) isLocalCluster(cluster string) bool {
+	return mwu.LocalClusterName != "" && cluster == mwu.LocalClusterName
+}
+
+// createOrUpdateLocalVRG creates or updates the VRG directly on the local cluster, in place of a VRG ManifestWork
+func (mwu *MWUtil) createOrUpdateLocalVRG(vrg rmn.VolumeReplicationGroup) error {
+	if err := ValidateVRGSpec(&vrg); err != nil {
+		return err
+	}
+
+	existingVRG := &rmn.VolumeReplicationGroup{}
+
+	err := mwu.Client.Get(mwu.Ctx, types.NamespacedName{Name: vrg.Name, Namespace: vrg.Namespace}, existingVRG)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get local VRG %s/%s: %w", vrg.Namespace, vrg.Name, err)
+		}
+
+		return mwu.Client.Create(mwu.Ctx, &vrg)
+	}
+
+	existingVRG.Spec = vrg.Spec
+
+	annotations := existingVRG.GetAnnotations()
+	UpdateStringMap(&annotations, vrg.GetAnnotations())
+	existingVRG.SetAnnotations(annotations)
+
+	return mwu.Client.Update(mwu.Ctx, existingVRG)
+}
+
+// setVRGForceResyncAnnotation stamps the VRG with a new ForceResyncAnnotation value if forceResync is set, and
+// otherwise carries forward the ForceResyncAnnotation value of existingVRG. The ReapplyAnnotation value of
+// existingVRG is always carried forward, to not undo or repeat a ReapplyVRGManifestWork.
+func setVRGForceResyncAnnotation(vrg, existingVRG *rmn.VolumeReplicationGroup, forceResync bool) {
+	existingAnnotations := map[string]string{}
+	if existingVRG != nil && existingVRG.GetAnnotations() != nil {
+		existingAnnotations = existingVRG.GetAnnotations()
+	}
+
+	carried := map[string]string{}
+
+	if forceResync {
+		carried[ForceResyncAnnotation] = time.Now().UTC().Format(time.RFC3339Nano)
+	} else if value, ok := existingAnnotations[ForceResyncAnnotation]; ok {
+		carried[ForceResyncAnnotation] = value
+	}
+
+	if value, ok := existingAnnotations[ReapplyAnnotation]; ok {
+		carried[ReapplyAnnotation] = value
+	}
+
+	if len(carried) == 0 {
+		return
+	}
+
+	vrgAnnotations := make(map[string]string, len(vrg.GetAnnotations())+len(carried))
+	UpdateStringMap(&vrgAnnotations, vrg.GetAnnotations())
+	UpdateStringMap(&vrgAnnotations, carried)
+	vrg.SetAnnotations(vrgAnnotations)
+}
+
+func (mwu *MWUtil) generateVRGManifestWork(name, namespace, homeCluster string,
+	vrg rmn.VolumeReplicationGroup, annotations map[string]string,
+) (*ocmworkv1.ManifestWork, error) {
+	vrgClientManifest, err := mwu.generateVRGManifest(vrg)
+	if err != nil {
+		mwu.Log.Error(err, "failed to generate VolumeReplicationGroup manifest")
+
+		return nil, err
+	}
+
+	manifests := []ocmworkv1.Manifest{*vrgClientManifest}
+
+	labels := make(map[string]string, len(mwu.VRGManifestWorkLabels)+1)
+	for key, value := range mwu.VRGManifestWorkLabels {
+		labels[key] = value
+	}
+
+	return mwu.newManifestWork(
+		fmt.Sprintf(ManifestWorkNameFormat, name, namespace, MWTypeVRG),
+		homeCluster,
+		labels,
+		manifests, annotations), nil
+}
+
+func (mwu *MWUtil) generateVRGManifest(vrg rmn.VolumeReplicationGroup) (*ocmworkv1.Manifest, error) {
+	if err := ValidateVRGSpec(&vrg); err != nil {
+		vrgManifestGenerationFailureCountIncrement(VRGManifestFailureKindValidation)
+
+		return nil, err
+	}
+
+	manifest, err := mwu.GenerateManifest(vrg)
+	if err != nil {
+		vrgManifestGenerationFailureCountIncrement(VRGManifestFailureKindMarshal)
+
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// MaintenanceMode ManifestWork creation
+func (mwu *MWUtil) CreateOrUpdateMModeManifestWork(
+	name, cluster string,
+	mMode rmn.MaintenanceMode, annotations map[string]string,
+) error {
+	manifestWork, err := mwu.generateMModeManifestWork(name, cluster, mMode, annotations)
+	if err != nil {
+		return err
+	}
+
+	mwu.Log.Info("Create or Update MaintenanceMode ManifestWork", "MW", ManifestWorkSummary(manifestWork))
+
+	return mwu.createOrUpdateManifestWork(manifestWork, cluster)
+}
+
+func (mwu *MWUtil) generateMModeManifestWork(name, cluster string,
+	mMode rmn.MaintenanceMode, annotations map[string]string,
+) (*ocmworkv1.ManifestWork, error) {
+	mModeManifest, err := mwu.generateMModeManifest(mMode)
+	if err != nil {
+		mwu.Log.Error(err, "failed to generate MaintenanceMode manifest")
+
+		return nil, err
+	}
+
+	manifests := []ocmworkv1.Manifest{*mModeManifest}
+
+	return mwu.newManifestWork(
+		fmt.Sprintf(ManifestWorkNameFormatClusterScope, name, MWTypeMMode),
+		cluster,
+		map[string]string{
+			MModesLabel: "",
+		},
+		manifests, annotations), nil
+}
+
+func (mwu *MWUtil) generateMModeManifest(mMode rmn.MaintenanceMode) (*ocmworkv1.Manifest, error) {
+	return mwu.GenerateManifest(mMode)
+}
+
+func (mwu *MWUtil) ListMModeManifests(cluster string) (*ocmworkv1.ManifestWorkList, error) {
+	matchLabels := map[string]string{
+		MModesLabel: "",
+	}
+	listOptions := []client.ListOption{
+		client.InNamespace(ClusterManifestWorkNamespace(cluster)),
+		client.MatchingLabels(matchLabels),
+	}
+
+	mModeMWs := &ocmworkv1.ManifestWorkList{}
+	err := mwu.APIReader.List(context.TODO(), mModeMWs, listOptions...)
+
+	return mModeMWs, err
+}
+
+// ListNotAppliedManifestWorks returns the ManifestWorks in the cluster namespace, matching selector if not nil,
+// that are not yet in applied state. Use ManagedByRamenSelector to scope the list to Ramen managed ManifestWorks.
+func (mwu *MWUtil) ListNotAppliedManifestWorks(
+	cluster string,
+	selector labels.Selector,
+) ([]ocmworkv1.ManifestWork, error) {
+	return mwu.listManifestWorks(cluster, selector, func(mw *ocmworkv1.ManifestWork) bool {
+		return !IsManifestInAppliedState(mw)
+	})
+}
+
+// listManifestWorks returns the ManifestWorks in the cluster namespace, matching selector if not nil, for which pred
+// returns true, listing them a page at a time to bound the size of each response
+func (mwu *MWUtil) listManifestWorks(
+	cluster string,
+	selector labels.Selector,
+	pred func(*ocmworkv1.ManifestWork) bool,
+) ([]ocmworkv1.ManifestWork, error) {
+	listOptions := []client.ListOption{
+		client.InNamespace(ClusterManifestWorkNamespace(cluster)),
+		client.Limit(manifestWorkListPageSize),
+	}
+
+	if selector != nil {
+		listOptions = append(listOptions, client.MatchingLabelsSelector{Selector: selector})
+	}
+
+	mws := []ocmworkv1.ManifestWork{}
+	continueToken := ""
+
+	for {
+		mwList := &ocmworkv1.ManifestWorkList{}
+
+		err := mwu.APIReader.List(mwu.Ctx, mwList, append(listOptions, client.Continue(continueToken))...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list ManifestWorks in %s: %w", cluster, err)
+		}
+
+		for i := range mwList.Items {
+			if pred(&mwList.Items[i]) {
+				mws = append(mws, mwList.Items[i])
+			}
+		}
+
+		continueToken = mwList.GetContinue()
+		if continueToken == "" {
+			return mws, nil
+		}
+	}
+}
+
+// IsManifestWorkLabeledManagedByRamen returns true if the ManifestWork carries the Ramen managed-by label, unlike
+// IsManifestWorkManagedByRamen which also recognizes ManifestWorks Ramen created before the label was introduced
+func IsManifestWorkLabeledManagedByRamen(mw *ocmworkv1.ManifestWork) bool {
+	return mw.GetLabels()[ManagedByLabel] == ManagedByLabelValue
+}
+
+// ManagedByRamenSelector selects ManifestWorks carrying the Ramen managed-by label
+func ManagedByRamenSelector() labels.Selector {
+	return labels.SelectorFromSet(labels.Set{ManagedByLabel: ManagedByLabelValue})
+}
+
+func ExtractVRGFromManifestWork(mw *ocmworkv1.ManifestWork) (*rmn.VolumeReplicationGroup, error) {
+	gvk := schema.GroupVersionKind{
+		Group:   rmn.GroupVersion.Group,
+		Version: rmn.GroupVersion.Version,
+		Kind:    "VolumeReplicationGroup",
+	}
+
+	rawObject, err := GetRawExtension(mw.Spec.Workload.Manifests, gvk)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching VolumeReplicationGroup from manifest %w", err)
+	}
+
+	if rawObject == nil {
+		return nil, nil
+	}
+
+	vrg := &rmn.VolumeReplicationGroup{}
+
+	err = json.Unmarshal(rawObject.Raw, vrg)
+	if err != nil {
+		return nil, fmt.Errorf("failed unmarshaling VolumeReplicationGroup from manifest %w", err)
+	}
+
+	return vrg, nil
+}
+
+func ExtractMModeFromManifestWork(mw *ocmworkv1.ManifestWork) (*rmn.MaintenanceMode, error) {
+	gvk := schema.GroupVersionKind{
+		Group:   rmn.GroupVersion.Group,
+		Version: rmn.GroupVersion.Version,
+		Kind:    "MaintenanceMode",
+	}
+
+	rawObject, err := GetRawExtension(mw.Spec.Workload.Manifests, gvk)
+	if err != nil {
+		return nil, fmt.Errorf("failed fetching MaintenanceMode from manifest %w", err)
+	}
+
+	if rawObject == nil {
+		return nil, nil
+	}
+
+	mMode := &rmn.MaintenanceMode{}
+
+	err = json.Unmarshal(rawObject.Raw, mMode)
+	if err != nil {
+		return nil, fmt.Errorf("failed unmarshaling MaintenanceMode from manifest %w", err)
+	}
+
+	return mMode, nil
+}
+
+// NetworkFence MW creation
+func (mwu *MWUtil) CreateOrUpdateNFManifestWork(
+	name, namespace, homeCluster string,
+	nf csiaddonsv1alpha1.NetworkFence, annotations map[string]string,
+) error {
+	manifestWork, err := mwu.generateNFManifestWork(name, namespace, homeCluster, nf, annotations)
+	if err != nil {
+		return err
+	}
+
+	mwu.Log.Info("Create or Update NetworkFence ManifestWork", "MW", ManifestWorkSummary(manifestWork))
+
+	return mwu.createOrUpdateManifestWork(manifestWork, homeCluster)
+}
+
+func (mwu *MWUtil) generateNFManifestWork(name, namespace, homeCluster string,
+	nf csiaddonsv1alpha1.NetworkFence, annotations map[string]string,
+) (*ocmworkv1.ManifestWork, error) {
+	nfClientManifest, err := mwu.generateNFManifest(nf)
+	if err != nil {
+		mwu.Log.Error(err, "failed to generate NetworkFence manifest")
+
+		return nil, err
+	}
+
+	manifests := []ocmworkv1.Manifest{*nfClientManifest}
+
+	// manifest work name for NetworkFence resource is
+	// "name-type-mw"
+	// name: name of the resource received from higher layer
+	//       that wants to create the csiaddonsv1alpha1.NetworkFence resource
+	// type: type of the resource for this ManifestWork
+	return mwu.newManifestWork(
+		fmt.Sprintf(ManifestWorkNameFormat, name, namespace, MWTypeNF),
+		homeCluster,
+		map[string]string{"app": "NF"},
+		manifests, annotations), nil
+}
+
+func (mwu *MWUtil) generateNFManifest(nf csiaddonsv1alpha1.NetworkFence) (*ocmworkv1.Manifest, error) {
+	return mwu.GenerateManifest(nf)
+}
+
+// CreateOrUpdateNamespaceManifest creates or updates a ManifestWork for the Namespace namespaceName on the managed
+// cluster. namespaceLabels and namespaceAnnotations, if not empty, are set on the Namespace itself, to meet
+// requirements such as pod security admission labels, whereas annotations are set on the ManifestWork.
+func (mwu *MWUtil) CreateOrUpdateNamespaceManifest(
+	name string, namespaceName string, managedClusterNamespace string,
+	annotations map[string]string, namespaceLabels, namespaceAnnotations map[string]string,
+) error {
+	return mwu.CreateOrUpdateNamespacesManifest(name, []string{namespaceName}, managedClusterNamespace,
+		annotations, namespaceLabels, namespaceAnnotations)
+}
+
+// CreateOrUpdateNamespacesManifest creates or updates a single Namespace ManifestWork for an application spanning
+// namespaceNames, named for the first of them, which is the VRG namespace. The namespace names must be distinct DNS
+// labels. Only the namespaces are created, the VRG protects the PVCs in its own namespace alone.
+//
+// TODO: Cover the PVCs in the other namespaces with the VRG, which requires the VRG spec, and the VRG reconciler on
+// the managed cluster, to support protecting multiple namespaces
+func (mwu *MWUtil) CreateOrUpdateNamespacesManifest(
+	name string, namespaceNames []string, managedClusterNamespace string,
+	annotations map[string]string, namespaceLabels, namespaceAnnotations map[string]string,
+) error {
+	if err := validateNamespaceNames(namespaceNames); err != nil {
+		return err
+	}
+
+	manifests := make([]ocmworkv1.Manifest, len(namespaceNames))
+
+	for i, namespaceName := range namespaceNames {
+		namespace := Namespace(namespaceName)
+		namespace.Labels = namespaceLabels
+		namespace.Annotations = namespaceAnnotations
+
+		manifest, err := mwu.GenerateManifest(namespace)
+		if err != nil {
+			return err
+		}
+
+		manifests[i] = *manifest
+	}
+
+	mwName := fmt.Sprintf(ManifestWorkNameFormat, name, namespaceNames[0], MWTypeNS)
+	manifestWork := mwu.newManifestWork(
+		mwName,
+		managedClusterNamespace,
+		map[string]string{
+			OCMBackupLabelKey: OCMBackupLabelValue,
+		},
+		manifests,
+		annotations)
+
+	return mwu.createOrUpdateManifestWork(manifestWork, managedClusterNamespace)
+}
+
+func validateNamespaceNames(namespaceNames []string) error {
+	if len(namespaceNames) == 0 {
+		return fmt.Errorf("no namespace names")
+	}
+
+	errs := []error{}
+	seen := sets.NewString()
+
+	for _, namespaceName := range namespaceNames {
+		if seen.Has(namespaceName) {
+			errs = append(errs, fmt.Errorf("namespace %q: duplicate", namespaceName))
+
+			continue
+		}
+
+		seen.Insert(namespaceName)
+
+		for _, msg := range validation.IsDNS1123Label(namespaceName) {
+			errs = append(errs, fmt.Errorf("namespace %q: %s", namespaceName, msg))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func Namespace(name string) *corev1.Namespace {
+	return &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{Kind: "Namespace", APIVersion: "v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+	}
+}
+
+func GetRawExtension(
+	manifests []ocmworkv1.Manifest,
+	gvk schema.GroupVersionKind,
+) (*runtime.RawExtension, error) {
+	for _, manifest := range manifests {
+		obj := &unstructured.Unstructured{}
+
+		err := json.Unmarshal(manifest.Raw, obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal JSON. Error %w", err)
+		}
+
+		objgvk := obj.GroupVersionKind()
+		if objgvk == gvk {
+			return &manifest.RawExtension, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (mwu *MWUtil) GetDrClusterManifestWork(clusterName string) (*ocmworkv1.ManifestWork, error) {
+	mw, err := mwu.FindManifestWork(DrClusterManifestWorkName, clusterName)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return mw, nil
+}
+
+// CreateOrUpdateDrClusterManifestWork creates or updates the DRCluster ManifestWork with the RBAC resources Ramen
+// requires on the cluster, followed by objectsToAppend and then extraManifests, such as the RamenConfig
+// DrClusterManifests. Each of extraManifests must be a well-formed object, as checked by ValidateManifestWork.
+func (mwu *MWUtil) CreateOrUpdateDrClusterManifestWork(
+	clusterName string,
+	objectsToAppend []interface{}, annotations map[string]string,
+	extraManifests ...runtime.RawExtension,
+) error {
+	objects, err := DrClusterObjects(mwu.restMapper(), objectsToAppend, extraManifests...)
+	if err != nil {
+		return fmt.Errorf("cluster %s extra manifests: %w", clusterName, err)
+	}
+
+	manifests := make([]ocmworkv1.Manifest, len(objects))
+
+	for i, object := range objects {
+		manifest, err := mwu.GenerateManifest(object)
+		if err != nil {
+			mwu.Log.Error(err, "failed to generate manifest", "object", object)
+
+			return err
+		}
+
+		manifests[i] = *manifest
+	}
+
+	return mwu.createOrUpdateManifestWork(
+		mwu.newManifestWork(
+			DrClusterManifestWorkName,
+			clusterName,
+			map[string]string{},
+			manifests, annotations,
+		),
+		clusterName,
+	)
+}
+
+// DrClusterObjects returns the objects of the DRCluster ManifestWork, the RBAC resources Ramen requires on the
+// cluster followed by objectsToAppend and then the objects in extraManifests, validated using mapper as per
+// ValidateManifestWork
+func DrClusterObjects(mapper meta.RESTMapper, objectsToAppend []interface{}, extraManifests ...runtime.RawExtension,
+) ([]interface{}, error) {
+	objects := append(
+		[]interface{}{
+			vrgClusterRole,
+			vrgClusterRoleBinding,
+			mModeClusterRole,
+			mModeClusterRoleBinding,
+		},
+		objectsToAppend...,
+	)
+
+	extraObjects, err := manifestObjects(extraManifests, mapper)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(objects, extraObjects...), nil
+}
+
+// ExportObjectsAsYAML returns objs, sanitized as for a ManifestWork by GenerateManifest, as a multi-document YAML
+// stream to apply using GitOps tools instead of OCM. Keys are sorted, so that the same objects export identically.
+func (mwu *MWUtil) ExportObjectsAsYAML(objs ...interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	for i, obj := range objs {
+		manifest, err := mwu.GenerateManifest(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		objYAML, err := yaml.JSONToYAML(manifest.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert object %d to YAML, error %w", i, err)
+		}
+
+		buf.WriteString("---\n")
+		buf.Write(objYAML)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ExportDRPCManifestWorks returns the objects embedded in the VRG ManifestWork of the DRPC name/namespace on each of
+// clusters, as a multi-document YAML stream for support bundles and offline debugging. Each object is annotated with
+// ExportedFromClusterAnnotation. Clusters without a VRG ManifestWork are skipped.
+func (mwu *MWUtil) ExportDRPCManifestWorks(name, namespace string, clusters []string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	mwName := ManifestWorkName(name, namespace, MWTypeVRG)
+
+	for _, cluster := range clusters {
+		mw, err := mwu.FindManifestWork(mwName, cluster)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		for i := range mw.Spec.Workload.Manifests {
+			objYAML, err := exportedManifest(mw.Spec.Workload.Manifests[i].Raw, cluster)
+			if err != nil {
+				return nil, fmt.Errorf("failed to export manifest %d of manifestwork %s/%s, error %w",
+					i, cluster, mwName, err)
+			}
+
+			buf.WriteString("---\n")
+			buf.Write(objYAML)
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func exportedManifest(raw []byte, cluster string) ([]byte, error) {
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(raw); err != nil {
+		return nil, err
+	}
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[ExportedFromClusterAnnotation] = cluster
+	obj.SetAnnotations(annotations)
+
+	objJSON, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.JSONToYAML(objJSON)
+}
+
+// VerifyDrClusterRBACApplied returns true once the per resource status of the DRCluster ManifestWork reports the
+// ClusterRoles and ClusterRoleBindings Ramen needs to manage VRGs and MaintenanceModes on the cluster as applied. It
+// returns ErrDrClusterRBACDegraded if any of them is reported degraded, which would cause a later failover to fail.
+func (mwu *MWUtil) VerifyDrClusterRBACApplied(cluster string) (bool, error) {
+	mw, err := mwu.GetDrClusterManifestWork(cluster)
+	if err != nil || mw == nil {
+		return false, err
+	}
+
+	applied := true
+
+	for _, object := range []client.Object{
+		vrgClusterRole,
+		vrgClusterRoleBinding,
+		mModeClusterRole,
+		mModeClusterRoleBinding,
+	} {
+		conditions := manifestResourceConditions(mw, rbacv1.GroupName,
+			object.GetObjectKind().GroupVersionKind().Kind, object.GetName())
+
+		if degraded := meta.FindStatusCondition(conditions, string(ocmworkv1.ManifestDegraded)); degraded != nil &&
+			degraded.Status == metav1.ConditionTrue {
+			return false, fmt.Errorf("cluster %s %s %s (%s: %s): %w", cluster,
+				object.GetObjectKind().GroupVersionKind().Kind, object.GetName(), degraded.Reason, degraded.Message,
+				ErrDrClusterRBACDegraded)
+		}
+
+		if !meta.IsStatusConditionTrue(conditions, string(ocmworkv1.ManifestApplied)) {
+			applied = false
+		}
+	}
+
+	return applied, nil
+}
+
+// ManifestWorkApplyErrors returns the distinct messages of the per resource Applied conditions that are false in the
+// ManifestWork status, in the order reported, which carry the actual reason a degraded ManifestWork failed to apply
+func (mwu *MWUtil) ManifestWorkApplyErrors(mw *ocmworkv1.ManifestWork) []string {
+	messages := []string{}
+	seen := sets.NewString()
+
+	for _, manifest := range mw.Status.ResourceStatus.Manifests {
+		condition := meta.FindStatusCondition(manifest.Conditions, string(ocmworkv1.ManifestApplied))
+		if condition == nil || condition.Status != metav1.ConditionFalse || seen.Has(condition.Message) {
+			continue
+		}
+
+		seen.Insert(condition.Message)
+		messages = append(messages, condition.Message)
+	}
+
+	return messages
+}
+
+// manifestResourceConditions returns the per resource status conditions of the resource with the passed in group,
+// kind and name in the ManifestWork, or nil if its status is not reported yet
+func manifestResourceConditions(mw *ocmworkv1.ManifestWork, group, kind, name string) []metav1.Condition {
+	for _, manifest := range mw.Status.ResourceStatus.Manifests {
+		if manifest.ResourceMeta.Group == group &&
+			manifest.ResourceMeta.Kind == kind &&
+			manifest.ResourceMeta.Name == name {
+			return manifest.Conditions
+		}
+	}
+
+	return nil
+}
+
+// manifestObjects validates and decodes raw manifests into objects to embed in a ManifestWork
+func manifestObjects(raws []runtime.RawExtension, mapper meta.RESTMapper) ([]interface{}, error) {
+	objects := make([]interface{}, 0, len(raws))
+	errs := []error{}
+
+	for i := range raws {
+		if err := validateManifest(&ocmworkv1.Manifest{RawExtension: raws[i]}, mapper); err != nil {
+			errs = append(errs, fmt.Errorf("manifest %d: %w", i, err))
+
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := obj.UnmarshalJSON(raws[i].Raw); err != nil {
+			errs = append(errs, fmt.Errorf("manifest %d: %w", i, err))
+
+			continue
+		}
+
+		objects = append(objects, obj)
+	}
+
+	return objects, utilerrors.NewAggregate(errs)
+}
+
+// UpdateDrClusterManifestWorkObjects replaces only the manifests of the passed in objects in the existing DRCluster
+// ManifestWork, matching each by kind, namespace and name, and leaves the remaining manifests untouched. It returns
+// ErrManifestWorkPartialUpdate if the ManifestWork or a matching manifest is absent, in which case callers should
+// fall back to CreateOrUpdateDrClusterManifestWork to regenerate the ManifestWork in full.
+func (mwu *MWUtil) UpdateDrClusterManifestWorkObjects(clusterName string, objects []interface{}) error {
+	mw, err := mwu.GetDrClusterManifestWork(clusterName)
+	if err != nil {
+		return err
+	}
+
+	if mw == nil {
+		return fmt.Errorf("cluster %s: %w", clusterName, ErrManifestWorkPartialUpdate)
+	}
+
+	manifests := make([]ocmworkv1.Manifest, len(mw.Spec.Workload.Manifests))
+	copy(manifests, mw.Spec.Workload.Manifests)
+
+	for _, object := range objects {
+		manifest, err := mwu.GenerateManifest(object)
+		if err != nil {
+			return err
+		}
+
+		index, err := manifestIndex(manifests, manifest)
+		if err != nil {
+			return fmt.Errorf("cluster %s: %w", clusterName, err)
+		}
+
+		manifests[index] = *manifest
+	}
+
+	labels := make(map[string]string, len(mw.GetLabels()))
+	UpdateStringMap(&labels, mw.GetLabels())
+
+	return mwu.createOrUpdateManifestWork(
+		mwu.newManifestWork(DrClusterManifestWorkName, clusterName, labels, manifests, mw.GetAnnotations()),
+		clusterName,
+	)
+}
+
+// manifestIndex returns the index of the manifest in manifests for the same object as manifest
+func manifestIndex(manifests []ocmworkv1.Manifest, manifest *ocmworkv1.Manifest) (int, error) {
+	object := &unstructured.Unstructured{}
+	if err := json.Unmarshal(manifest.Raw, object); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal JSON. Error %w", err)
+	}
+
+	for i := range manifests {
+		existing := &unstructured.Unstructured{}
+		if err := json.Unmarshal(manifests[i].Raw, existing); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal JSON. Error %w", err)
+		}
+
+		if existing.GroupVersionKind() == object.GroupVersionKind() &&
+			existing.GetNamespace() == object.GetNamespace() &&
+			existing.GetName() == object.GetName() {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%s %s/%s: %w", object.GetKind(), object.GetNamespace(), object.GetName(),
+		ErrManifestWorkPartialUpdate)
+}
+
+var (
+	vrgClusterRole = &rbacv1.ClusterRole{
+		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRole", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "open-cluster-management:klusterlet-work-sa:agent:volrepgroup-edit"},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{"ramendr.openshift.io"},
+				Resources: []string{"volumereplicationgroups"},
+				Verbs:     []string{"create", "get", "list", "update", "delete"},
+			},
+		},
+	}
+
+	vrgClusterRoleBinding = &rbacv1.ClusterRoleBinding{
+		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "open-cluster-management:klusterlet-work-sa:agent:volrepgroup-edit"},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      "klusterlet-work-sa",
+				Namespace: "open-cluster-management-agent",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     "open-cluster-management:klusterlet-work-sa:agent:volrepgroup-edit",
+		},
+	}
+
+	mModeClusterRole = &rbacv1.ClusterRole{
+		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRole", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "open-cluster-management:klusterlet-work-sa:agent:mmode-edit"},
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{"ramendr.openshift.io"},
+				Resources: []string{"maintenancemodes"},
+				Verbs:     []string{"create", "get", "list", "update", "delete"},
+			},
+		},
+	}
+
+	mModeClusterRoleBinding = &rbacv1.ClusterRoleBinding{
+		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "open-cluster-management:klusterlet-work-sa:agent:mmode-edit"},
+		Subjects: []rbacv1.Subject{
+			{
+				Kind:      "ServiceAccount",
+				Name:      "klusterlet-work-sa",
+				Namespace: "open-cluster-management-agent",
+			},
+		},
+		RoleRef: rbacv1.RoleRef{
+			APIGroup: "rbac.authorization.k8s.io",
+			Kind:     "ClusterRole",
+			Name:     "open-cluster-management:klusterlet-work-sa:agent:mmode-edit",
+		},
+	}
+)
+
+// GenerateManifest generates a manifest for obj, sanitized of the server populated metadata fields and, unless
+// PreserveManifestStatus is set, of its status. A typed obj must be of a kind accepted by ValidateManifestGVK.
+func (mwu *MWUtil) GenerateManifest(obj interface{}) (*ocmworkv1.Manifest, error) {
+	switch u := obj.(type) {
+	case *unstructured.Unstructured:
+		return mwu.generateManifestFromUnstructured(u)
+	case unstructured.Unstructured:
+		return mwu.generateManifestFromUnstructured(&u)
+	}
+
+	objJSON, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %v to JSON, error %w", obj, err)
+	}
+
+	if err := ValidateManifestGVK(objJSON); err != nil {
+		return nil, err
+	}
+
+	objJSON, err = mwu.sanitizeManifestJSON(objJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sanitize %v, error %w", obj, err)
+	}
+
+	manifest := &ocmworkv1.Manifest{}
+	manifest.RawExtension = runtime.RawExtension{Raw: objJSON}
+
+	return manifest, nil
+}
+
+// sanitizeManifestJSON removes the metadata fields populated by the API server, which are meaningless on the managed
+// cluster, and the status unless PreserveManifestStatus is set, from the JSON of an object, and stamps it with the
+// HubName if set. JSON that is not an object is returned as is.
+func (mwu *MWUtil) sanitizeManifestJSON(objJSON []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(objJSON))
+	decoder.UseNumber()
+
+	object := map[string]interface{}{}
+	if err := decoder.Decode(&object); err != nil {
+		return objJSON, nil //nolint:nilerr
+	}
+
+	sanitizeManifestObject(object, mwu.PreserveManifestStatus)
+	stampManifestHubName(object, mwu.HubName)
+
+	return json.Marshal(object)
+}
+
+func sanitizeManifestObject(object map[string]interface{}, preserveStatus bool) {
+	if metadata, ok := object["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"managedFields", "resourceVersion", "uid", "creationTimestamp"} {
+			delete(metadata, field)
+		}
+	}
+
+	if !preserveStatus {
+		delete(object, "status")
+	}
+}
+
+// stampManifestHubName sets the HubNameAnnotation of the object to hubName, unless hubName is empty
+func stampManifestHubName(object map[string]interface{}, hubName string) {
+	if hubName == "" {
+		return
+	}
+
+	metadata, ok := object["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = map[string]interface{}{}
+		object["metadata"] = metadata
+	}
+
+	annotations, ok := metadata["annotations"].(map[string]interface{})
+	if !ok {
+		annotations = map[string]interface{}{}
+		metadata["annotations"] = annotations
+	}
+
+	annotations[HubNameAnnotation] = hubName
+}
+
+// generateManifestFromUnstructured generates a manifest from the Object map of the unstructured object, which is
+// required to carry an apiVersion and kind for the managed cluster to apply it
+func (mwu *MWUtil) generateManifestFromUnstructured(u *unstructured.Unstructured) (*ocmworkv1.Manifest, error) {
+	if u == nil || u.Object == nil {
+		return nil, fmt.Errorf("unstructured object has no content")
+	}
+
+	if u.GetAPIVersion() == "" || u.GetKind() == "" {
+		return nil, fmt.Errorf("unstructured object %s/%s is missing apiVersion or kind",
+			u.GetNamespace(), u.GetName())
+	}
+
+	u = u.DeepCopy()
+	sanitizeManifestObject(u.Object, mwu.PreserveManifestStatus)
+	stampManifestHubName(u.Object, mwu.HubName)
+
+	objJSON, err := json.Marshal(u.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s %s/%s to JSON, error %w",
+			u.GetKind(), u.GetNamespace(), u.GetName(), err)
+	}
+
+	return &ocmworkv1.Manifest{RawExtension: runtime.RawExtension{Raw: objJSON}}, nil
+}
+
+func (mwu *MWUtil) newManifestWork(name string, cluster string,
+	labels map[string]string, manifests []ocmworkv1.Manifest, annotations map[string]string,
+) *ocmworkv1.ManifestWork {
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	labels[ManagedByLabel] = ManagedByLabelValue
+
+	mw := &ocmworkv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ClusterManifestWorkNamespace(cluster),
+			Labels:    labels,
+		},
+		Spec: ocmworkv1.ManifestWorkSpec{
+			Workload: ocmworkv1.ManifestsTemplate{
+				Manifests: manifests,
+			},
+		},
+	}
+
+	mw.ObjectMeta.Annotations = map[string]string{}
+	for key, value := range annotations {
+		mw.ObjectMeta.Annotations[key] = value
+	}
+
+	mw.ObjectMeta.Annotations[OperationIDAnnotation] = mwu.operationID()
+
+	if mwu.ProtectionFinalizer {
+		controllerutil.AddFinalizer(mw, ManifestWorkProtectionFinalizer)
+	}
+
+	return mw
+}
+
+func (mwu *MWUtil) operationID() string {
+	if mwu.OperationID == "" {
+		mwu.OperationID = uuid.New().String()
+	}
+
+	return mwu.OperationID
+}
+
+func (mwu *MWUtil) createOrUpdateManifestWork(
+	mw *ocmworkv1.ManifestWork,
+	managedClusternamespace string,
+) error {
+	_, err := mwu.createOrUpdateManifestWorkAndGet(mw, managedClusternamespace)
+
+	return err
+}
+
+// createOrUpdateManifestWorkAndGet creates or updates mw, and returns the ManifestWork as last read from or written
+// to the hub, including its status
+func (mwu *MWUtil) createOrUpdateManifestWorkAndGet(
+	mw *ocmworkv1.ManifestWork,
+	managedClusternamespace string,
+) (*ocmworkv1.ManifestWork, error) {
+	return mwu.createOrUpdateManifestWorkFrom(mw, managedClusternamespace, func() (*ocmworkv1.ManifestWork, error) {
+		return mwu.getManifestWorkIfExists(mw.Name, managedClusternamespace)
+	})
+}
+
+// getManifestWorkIfExists returns the ManifestWork mwName for cluster, or nil if it does not exist
+func (mwu *MWUtil) getManifestWorkIfExists(mwName, cluster string) (*ocmworkv1.ManifestWork, error) {
+	mw := &ocmworkv1.ManifestWork{}
+
+	err := mwu.Client.Get(mwu.Ctx,
+		types.NamespacedName{Name: mwName, Namespace: ClusterManifestWorkNamespace(cluster)}, mw)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, errorswrapper.Wrap(err, fmt.Sprintf("failed to fetch ManifestWork %s", mwName))
+	}
+
+	return mw, nil
+}
+
+// createOrUpdateManifestWorkFrom creates or updates mw as createOrUpdateManifestWorkAndGet does, given
+// findExisting that returns the existing ManifestWork, or nil if it does not exist. findExisting is only called if
+// the existing ManifestWork is needed, and is not called again unless the update conflicts.
+func (mwu *MWUtil) createOrUpdateManifestWorkFrom(
+	mw *ocmworkv1.ManifestWork,
+	managedClusternamespace string,
+	findExisting func() (*ocmworkv1.ManifestWork, error),
+) (*ocmworkv1.ManifestWork, error) {
+	if err := mwu.checkClusterNotPaused(managedClusternamespace); err != nil {
+		return nil, err
+	}
+
+	if err := validateManifestWorkSize(mw); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateManifestWork(mw, mwu.restMapper()); err != nil {
+		return nil, err
+	}
+
+	specHash := ManifestWorkSpecHash(mw.Spec)
+
+	if mw.Annotations == nil {
+		mw.Annotations = map[string]string{}
+	}
+
+	mw.Annotations[SpecHashAnnotation] = specHash
+
+	foundMW, err := findExisting()
+	if err != nil {
+		return nil, err
+	}
+
+	if foundMW == nil {
+		// Let DRPC receive notification for any changes to ManifestWork CR created by it.
+		// if err := ctrl.SetControllerReference(d.instance, mw, d.reconciler.Scheme); err != nil {
+		//	return fmt.Errorf("failed to set owner reference to ManifestWork resource (%s/%s) (%v)",
+		//		mw.Name, mw.Namespace, err)
+		// }
+
+		if err := mwu.checkClusterNamespaceExists(managedClusternamespace); err != nil {
+			return nil, err
+		}
+
+		if mwu.ServerSideApply {
+			return mwu.applyManifestWork(mw, managedClusternamespace, MWActionCreate)
+		}
+
+		mwu.Log.Info("Creating ManifestWork", "MW", ManifestWorkSummary(mw))
+
+		manifestWorkReconcileCountIncrement(MWActionCreate, mw.Name)
+
+		if err := mwu.Client.Create(mwu.Ctx, mw); err != nil {
+			mwu.reportEvent(corev1.EventTypeWarning, EventReasonManifestWorkCreateFailed,
+				fmt.Sprintf("failed to create ManifestWork %s/%s: %v", managedClusternamespace, mw.Name, err))
+
+			return nil, err
+		}
+
+		mwu.reportEvent(corev1.EventTypeNormal, EventReasonManifestWorkCreated,
+			fmt.Sprintf("created ManifestWork %s/%s", managedClusternamespace, mw.Name))
+
+		return mw, nil
+	}
+
+	if !foundMW.GetDeletionTimestamp().IsZero() {
+		return nil, fmt.Errorf("ManifestWork %s/%s: %w", managedClusternamespace, mw.Name, ErrManifestWorkTerminating)
+	}
+
+	// Compare the hash of the spec on the hub first, to avoid comparing the manifests in full when unchanged. The
+	// hash is computed from the spec as read rather than taken from the SpecHashAnnotation, so that a spec changed by
+	// other than Ramen is reverted. ManifestWorks created before the managed-by label was introduced are updated to
+	// add it.
+	if IsManifestWorkLabeledManagedByRamen(foundMW) && manifestWorkLabelsPresent(foundMW, mw) &&
+		manifestWorkSourceGenerationStamped(foundMW, mw) &&
+		(!mwu.ProtectionFinalizer || controllerutil.ContainsFinalizer(foundMW, ManifestWorkProtectionFinalizer)) &&
+		(ManifestWorkSpecHash(foundMW.Spec) == specHash || ManifestWorkSpecEqual(foundMW.Spec, mw.Spec)) {
+		manifestWorkReconcileCountIncrement(MWActionNoop, mw.Name)
+
+		return foundMW, nil
+	}
+
+	if mwu.ServerSideApply {
+		return mwu.applyManifestWork(mw, managedClusternamespace, MWActionUpdate)
+	}
+
+	mwu.Log.Info("Updating ManifestWork", "MW", ManifestWorkSummary(foundMW))
+
+	attempted := false
+
+	retryErr := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		// The ManifestWork already read is updated first, and read again only if the update conflicts
+		if attempted {
+			latestMW := &ocmworkv1.ManifestWork{}
+
+			err := mwu.Client.Get(mwu.Ctx,
+				types.NamespacedName{Name: mw.Name, Namespace: ClusterManifestWorkNamespace(managedClusternamespace)},
+				latestMW)
+			if err != nil {
+				return err
+			}
+
+			foundMW = latestMW
+		}
+
+		attempted = true
+
+		// The manifests are replaced as a whole, rather than merged, so that manifests no longer generated are
+		// dropped, and the work agent in turn deletes the resources it applied for them on the managed cluster
+		mw.Spec.DeepCopyInto(&foundMW.Spec)
+
+		annotations := foundMW.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		if operationID, ok := mw.GetAnnotations()[OperationIDAnnotation]; ok {
+			annotations[OperationIDAnnotation] = operationID
+		}
+
+		annotations[SpecHashAnnotation] = specHash
+
+		// A ManifestWork updated other than for a source generation no longer reflects the one it is stamped with
+		if sourceGeneration, ok := mw.GetAnnotations()[SourceGenerationAnnotation]; ok {
+			annotations[SourceGenerationAnnotation] = sourceGeneration
+		} else {
+			delete(annotations, SourceGenerationAnnotation)
+		}
+
+		foundMW.SetAnnotations(annotations)
+
+		labels := foundMW.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+
+		// The labels of mw are set over those of the existing ManifestWork, with the Ramen labels set last to take
+		// precedence. Labels set by others are retained.
+		for key, value := range mw.GetLabels() {
+			labels[key] = value
+		}
+
+		labels[ManagedByLabel] = ManagedByLabelValue
+		foundMW.SetLabels(labels)
+
+		if mwu.ProtectionFinalizer {
+			controllerutil.AddFinalizer(foundMW, ManifestWorkProtectionFinalizer)
+		}
+
+		return mwu.Client.Update(mwu.Ctx, foundMW)
+	})
+	if retryErr != nil {
+		mwu.reportEvent(corev1.EventTypeWarning, EventReasonManifestWorkUpdateFailed,
+			fmt.Sprintf("failed to update ManifestWork %s/%s: %v", managedClusternamespace, mw.Name, retryErr))
+
+		return nil, retryErr
+	}
+
+	manifestWorkReconcileCountIncrement(MWActionUpdate, mw.Name)
+	mwu.reportEvent(corev1.EventTypeNormal, EventReasonManifestWorkUpdated,
+		fmt.Sprintf("updated ManifestWork %s/%s", managedClusternamespace, mw.Name))
+
+	return foundMW, nil
+}
+
+// manifestWorkSourceGenerationStamped returns true if found carries the SourceGenerationAnnotation value of mw, or
+// neither carries one
+func manifestWorkSourceGenerationStamped(found, mw *ocmworkv1.ManifestWork) bool {
+	return found.GetAnnotations()[SourceGenerationAnnotation] == mw.GetAnnotations()[SourceGenerationAnnotation]
+}
+
+// manifestWorkLabelsPresent returns true if found carries every label of mw, with the same value. Labels are never
+// removed from an existing ManifestWork, so other labels found do not require an update.
+func manifestWorkLabelsPresent(found, mw *ocmworkv1.ManifestWork) bool {
+	foundLabels := found.GetLabels()
+
+	for key, value := range mw.GetLabels() {
+		if foundValue, ok := foundLabels[key]; !ok || foundValue != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ManifestWorkSpecHash returns a stable hash of the manifests in spec. Each manifest is canonicalized by decoding and
+// re-encoding it, so that differences in field order or whitespace alone do not change the hash.
+func ManifestWorkSpecHash(spec ocmworkv1.ManifestWorkSpec) string {
+	hash := sha256.New()
+
+	for i := range spec.Workload.Manifests {
+		raw := spec.Workload.Manifests[i].Raw
+
+		var object interface{}
+
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&object); err == nil {
+			if canonical, err := json.Marshal(object); err == nil {
+				raw = canonical
+			}
+		}
+
+		hash.Write(raw)
+		hash.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// ManifestWorkSpecEqual returns true if the ManifestWork specs carry the same manifests, once normalized to ignore
+// differences that do not change the applied objects, so that a ManifestWork read back from the hub compares equal
+// to the one Ramen generated for it. Ignored are:
+//   - the JSON encoding of a manifest, i.e. its key order, whitespace and number formatting
+//   - the metadata fields populated by the API server, i.e. managedFields, resourceVersion, uid and
+//     creationTimestamp, which older releases included as null
+//   - an empty status, which older releases included for typed objects
+//
+// The ManifestWork API version in use has no OCM defaulted spec fields, such as a DeleteOption or ManifestConfigs,
+// for the comparison to ignore.
+func ManifestWorkSpecEqual(a, b ocmworkv1.ManifestWorkSpec) bool {
+	if len(a.Workload.Manifests) != len(b.Workload.Manifests) {
+		return false
+	}
+
+	for i := range a.Workload.Manifests {
+		if !reflect.DeepEqual(normalizedManifest(a.Workload.Manifests[i].Raw),
+			normalizedManifest(b.Workload.Manifests[i].Raw)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func normalizedManifest(raw []byte) interface{} {
+	var object interface{}
+	if err := json.Unmarshal(raw, &object); err != nil {
+		return raw
+	}
+
+	if objectMap, ok := object.(map[string]interface{}); ok {
+		sanitizeManifestObject(objectMap, true)
+
+		if status, ok := objectMap["status"].(map[string]interface{}); ok && len(status) == 0 {
+			delete(objectMap, "status")
+		}
+	}
+
+	return object
+}
+
+// manifestGVKs are the kinds of typed objects Ramen ships to managed clusters in ManifestWorks
+var manifestGVKs = struct {
+	sync.RWMutex
+	gvks map[schema.GroupVersionKind]struct{}
+}{
+	gvks: map[schema.GroupVersionKind]struct{}{
+		{Group: "", Version: "v1", Kind: "ConfigMap"}:                                   {},
+		{Group: "", Version: "v1", Kind: "Namespace"}:                                   {},
+		{Group: "csiaddons.openshift.io", Version: "v1alpha1", Kind: "NetworkFence"}:    {},
+		{Group: "operators.coreos.com", Version: "v1", Kind: "OperatorGroup"}:           {},
+		{Group: "operators.coreos.com", Version: "v1alpha1", Kind: "Subscription"}:      {},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRole"}:        {},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "ClusterRoleBinding"}: {},
+		{Group: "rbac.authorization.k8s.io", Version: "v1", Kind: "RoleBinding"}:        {},
+		rmn.GroupVersion.WithKind("MaintenanceMode"):                                    {},
+		rmn.GroupVersion.WithKind("VolumeReplicationGroup"):                             {},
+	},
+}
+
+// RegisterManifestGVKs adds gvks to the kinds of typed objects that GenerateManifest accepts, for features that ship
+// additional kinds to managed clusters, such as recipes
+func RegisterManifestGVKs(gvks ...schema.GroupVersionKind) {
+	manifestGVKs.Lock()
+	defer manifestGVKs.Unlock()
+
+	for _, gvk := range gvks {
+		manifestGVKs.gvks[gvk] = struct{}{}
+	}
+}
+
+// ValidateManifestGVK returns an error wrapping ErrUnexpectedManifestKind if the apiVersion and kind of the object
+// JSON is not one Ramen ships to managed clusters, as registered using RegisterManifestGVKs. GenerateManifest checks
+// typed objects only, to catch shipping a wrong or mistyped struct, as unstructured objects, such as the RamenConfig
+// DrClusterManifests, are of kinds chosen by the administrator.
+func ValidateManifestGVK(objJSON []byte) error {
+	typeMeta := metav1.TypeMeta{}
+	if err := json.Unmarshal(objJSON, &typeMeta); err != nil {
+		return fmt.Errorf("invalid object: %w", err)
+	}
+
+	gvk := typeMeta.GroupVersionKind()
+
+	manifestGVKs.RLock()
+	defer manifestGVKs.RUnlock()
+
+	if _, ok := manifestGVKs.gvks[gvk]; !ok {
+		return fmt.Errorf("apiVersion %q kind %q: %w", typeMeta.APIVersion, typeMeta.Kind, ErrUnexpectedManifestKind)
+	}
+
+	return nil
+}
+
+// ValidateManifestWork checks that each manifest in the ManifestWork decodes into an object with an apiVersion, kind
+// and name, and that objects of namespaced kinds have a namespace. The scope of each kind is resolved using mapper,
+// and the namespace is not checked for kinds the mapper cannot resolve, such as kinds served by the managed clusters
+// only, or if mapper is nil. All invalid manifests are reported in the returned aggregate error.
+func ValidateManifestWork(mw *ocmworkv1.ManifestWork, mapper meta.RESTMapper) error {
+	errs := []error{}
+
+	for i := range mw.Spec.Workload.Manifests {
+		if err := validateManifest(&mw.Spec.Workload.Manifests[i], mapper); err != nil {
+			errs = append(errs, fmt.Errorf("ManifestWork %s/%s manifest %d: %w", mw.GetNamespace(), mw.GetName(), i,
+				err))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func validateManifest(manifest *ocmworkv1.Manifest, mapper meta.RESTMapper) error {
+	obj := &unstructured.Unstructured{}
+	if err := obj.UnmarshalJSON(manifest.Raw); err != nil {
+		return fmt.Errorf("invalid object: %w", err)
+	}
+
+	gvk := obj.GroupVersionKind()
+
+	if gvk.Version == "" || gvk.Kind == "" {
+		return fmt.Errorf("missing apiVersion or kind")
+	}
+
+	if obj.GetName() == "" {
+		return fmt.Errorf("%s missing name", gvk.Kind)
+	}
+
+	if obj.GetNamespace() == "" && kindNamespaced(mapper, gvk) {
+		return fmt.Errorf("%s %s missing namespace", gvk.Kind, obj.GetName())
+	}
+
+	return nil
+}
+
+// kindNamespaced returns true if mapper resolves gvk to a namespaced resource, and false if it is cluster scoped or
+// cannot be resolved
+func kindNamespaced(mapper meta.RESTMapper, gvk schema.GroupVersionKind) bool {
+	if mapper == nil {
+		return false
+	}
+
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return false
+	}
+
+	return mapping.Scope.Name() == meta.RESTScopeNameNamespace
+}
+
+// ManifestWorkSize returns the total size in bytes of the raw manifests in the ManifestWork
+func ManifestWorkSize(mw *ocmworkv1.ManifestWork) int {
+	size := 0
+
+	for i := range mw.Spec.Workload.Manifests {
+		size += len(mw.Spec.Workload.Manifests[i].Raw)
+	}
+
+	return size
+}
+
+func validateManifestWorkSize(mw *ocmworkv1.ManifestWork) error {
+	if size := ManifestWorkSize(mw); size > ManifestWorkSizeLimit {
+		return fmt.Errorf("ManifestWork %s/%s manifests are %d bytes, exceeding the %d byte limit, "+
+			"consider splitting them across multiple ManifestWorks: %w",
+			mw.Namespace, mw.Name, size, ManifestWorkSizeLimit, ErrManifestWorkTooLarge)
+	}
+
+	return nil
+}
+
+func (mwu *MWUtil) reportEvent(eventType, reason, msg string) {
+	if mwu.EventRecorder == nil || mwu.EventObject == nil {
+		return
+	}
+
+	ReportIfNotPresent(mwu.EventRecorder, mwu.EventObject, eventType, reason, msg)
+}
+
+// applyManifestWork creates, if action is MWActionCreate, or else updates the ManifestWork using server-side apply
+// with MWFieldManager as the field manager, and returns it as applied. Ownership is not forced, so fields owned by
+// another manager result in a conflict error instead of being overwritten.
+func (mwu *MWUtil) applyManifestWork(
+	mw *ocmworkv1.ManifestWork,
+	managedClusternamespace, action string,
+) (*ocmworkv1.ManifestWork, error) {
+	mw.TypeMeta = metav1.TypeMeta{Kind: "ManifestWork", APIVersion: ocmworkv1.GroupVersion.String()}
+	mw.Namespace = ClusterManifestWorkNamespace(managedClusternamespace)
+	mw.ManagedFields = nil
+
+	if mwu.ProtectionFinalizer {
+		controllerutil.AddFinalizer(mw, ManifestWorkProtectionFinalizer)
+	}
+
+	failedReason, reason := EventReasonManifestWorkUpdateFailed, EventReasonManifestWorkUpdated
+	if action == MWActionCreate {
+		failedReason, reason = EventReasonManifestWorkCreateFailed, EventReasonManifestWorkCreated
+	}
+
+	mwu.Log.Info("Applying ManifestWork", "MW", ManifestWorkSummary(mw), "action", action)
+
+	err := mwu.Client.Patch(mwu.Ctx, mw, client.Apply, client.FieldOwner(MWFieldManager))
+	if err != nil {
+		mwu.reportEvent(corev1.EventTypeWarning, failedReason,
+			fmt.Sprintf("failed to apply ManifestWork %s/%s: %v", managedClusternamespace, mw.Name, err))
+
+		return nil, errorswrapper.Wrap(err, fmt.Sprintf("failed to apply ManifestWork %s", mw.Name))
+	}
+
+	manifestWorkReconcileCountIncrement(action, mw.Name)
+	mwu.reportEvent(corev1.EventTypeNormal, reason,
+		fmt.Sprintf("applied ManifestWork %s/%s", managedClusternamespace, mw.Name))
+
+	return mw, nil
+}
+
+// MigrateManifestWorkNames migrates the ManifestWorks of the passed in types on the cluster, from the name
+// generated by oldName to the name generated by newName. See MigrateManifestWorkName.
+func (mwu *MWUtil) MigrateManifestWorkNames(cluster string, mwTypes []string,
+	oldName, newName ManifestWorkNameFunc,
+) error {
+	for _, mwType := range mwTypes {
+		if err := mwu.MigrateManifestWorkName(cluster, mwType, oldName, newName); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// MigrateManifestWorkName creates a ManifestWork named using newName, with the labels, annotations and manifests of
+// the ManifestWork named using oldName. The old ManifestWork is deleted once the new one is applied, so that
+// resources on the managed cluster are never without an owning ManifestWork; until then
+// ErrManifestWorkMigrationPending is returned for the caller to requeue. It is a no-op if the old ManifestWork does
+// not exist, making it safe to call on every reconcile.
+func (mwu *MWUtil) MigrateManifestWorkName(cluster, mwType string, oldName, newName ManifestWorkNameFunc) error {
+	oldMWName := oldName(mwu.InstName, mwu.TargetNamespace, mwType)
+	newMWName := newName(mwu.InstName, mwu.TargetNamespace, mwType)
+
+	if oldMWName == newMWName {
+		return nil
+	}
+
+	oldMW, err := mwu.FindManifestWork(oldMWName, cluster)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if !IsManifestWorkManagedByRamen(oldMW) ||
+		oldMW.GetAnnotations()[DRPCNameAnnotation] != mwu.InstName {
+		return fmt.Errorf("ManifestWork %s/%s not migrated: %w", cluster, oldMWName, ErrManifestWorkNotManaged)
+	}
+
+	labels := make(map[string]string, len(oldMW.GetLabels()))
+	UpdateStringMap(&labels, oldMW.GetLabels())
+
+	annotations := make(map[string]string, len(oldMW.GetAnnotations()))
+	UpdateStringMap(&annotations, oldMW.GetAnnotations())
+
+	newMW := mwu.newManifestWork(newMWName, cluster, labels, oldMW.Spec.Workload.Manifests, annotations)
+
+	if err := mwu.createOrUpdateManifestWork(newMW, cluster); err != nil {
+		return fmt.Errorf("failed to migrate ManifestWork %s/%s to %s: %w", cluster, oldMWName, newMWName, err)
+	}
+
+	newMW, err = mwu.FindManifestWork(newMWName, cluster)
+	if err != nil {
+		return err
+	}
+
+	if !IsManifestInAppliedState(newMW) {
+		return fmt.Errorf("ManifestWork %s/%s not yet applied: %w", cluster, newMWName, ErrManifestWorkMigrationPending)
+	}
+
+	mwu.Log.Info("Migrated ManifestWork", "cluster", cluster, "from", oldMWName, "to", newMWName)
+
+	return mwu.DeleteManifestWork(oldMWName, cluster)
+}
+
+// RelocateVRGManifestWork moves the VRG ManifestWork from fromCluster to toCluster, carrying forward the
+// annotations of the ManifestWork on fromCluster. The ManifestWork on fromCluster is deleted only once the one on
+// toCluster is applied, so that the workload is never without a VRG; until then ErrManifestWorkRelocationPending is
+// returned for the caller to requeue. A call retried after a partial relocation completes it.
+func (mwu *MWUtil) RelocateVRGManifestWork(name, namespace, fromCluster, toCluster string,
+	vrg rmn.VolumeReplicationGroup, opts ...VRGOption,
+) error {
+	if fromCluster == toCluster {
+		return fmt.Errorf("VRG ManifestWork %s/%s cannot be relocated to its own cluster %s", namespace, name, toCluster)
+	}
+
+	mwName := ManifestWorkName(name, namespace, MWTypeVRG)
+
+	var annotations map[string]string
+
+	fromMW, err := mwu.FindManifestWork(mwName, fromCluster)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	} else {
+		if !IsManifestWorkManagedByRamen(fromMW) {
+			return fmt.Errorf("ManifestWork %s/%s not relocated: %w", fromCluster, mwName, ErrManifestWorkNotManaged)
+		}
+
+		annotations = fromMW.GetAnnotations()
+	}
+
+	if err := mwu.CreateOrUpdateVRGManifestWork(name, namespace, toCluster, vrg, annotations, false,
+		opts...); err != nil {
+		return fmt.Errorf("failed to relocate ManifestWork %s from %s to %s: %w", mwName, fromCluster, toCluster, err)
+	}
+
+	toMW, err := mwu.FindManifestWork(mwName, toCluster)
+	if err != nil {
+		return err
+	}
+
+	if !IsManifestInAppliedState(toMW) {
+		return fmt.Errorf("ManifestWork %s/%s not yet applied: %w", toCluster, mwName, ErrManifestWorkRelocationPending)
+	}
+
+	if fromMW == nil {
+		return nil
+	}
+
+	mwu.Log.Info("Relocated ManifestWork", "name", mwName, "from", fromCluster, "to", toCluster)
+
+	return mwu.DeleteManifestWork(mwName, fromCluster)
+}
+
+// PromoteVRG creates or updates the VRG ManifestWork on targetCluster with vrg as Primary. If requireSourceDemoted is
+// set, the VRG ManifestWork on sourceCluster must first be applied with the VRG as Secondary, else
+// ErrSourceVRGNotDemoted is returned for the caller to requeue, so that both clusters never run a Primary VRG. A
+// sourceCluster without the VRG ManifestWork, or that the hub cannot reach, does not block the promotion.
+func (mwu *MWUtil) PromoteVRG(name, namespace, targetCluster string, vrg rmn.VolumeReplicationGroup,
+	requireSourceDemoted bool, sourceCluster string, opts ...VRGOption,
+) error {
+	mwName := ManifestWorkName(name, namespace, MWTypeVRG)
+
+	var annotations map[string]string
+
+	if requireSourceDemoted {
+		if sourceCluster == targetCluster {
+			return fmt.Errorf("VRG %s/%s source and target cluster are both %s", namespace, name, targetCluster)
+		}
+
+		sourceMW, err := mwu.FindManifestWork(mwName, sourceCluster)
+
+		switch {
+		case errors.IsNotFound(err), errorswrapper.Is(err, ErrClusterUnreachable):
+			mwu.Log.Info("Promoting VRG without a reachable source VRG", "name", mwName, "source", sourceCluster,
+				"reason", err.Error())
+		case err != nil:
+			return err
+		default:
+			if err := mwu.checkVRGDemoted(sourceMW); err != nil {
+				return fmt.Errorf("VRG %s/%s on %s: %w", namespace, name, sourceCluster, err)
+			}
+
+			annotations = sourceMW.GetAnnotations()
+		}
+	}
+
+	vrg.Spec.ReplicationState = rmn.Primary
+
+	return mwu.CreateOrUpdateVRGManifestWork(name, namespace, targetCluster, vrg, annotations, false, opts...)
+}
+
+func (mwu *MWUtil) checkVRGDemoted(mw *ocmworkv1.ManifestWork) error {
+	vrg, err := ExtractVRGFromManifestWork(mw)
+	if err != nil {
+		return err
+	}
+
+	if vrg.Spec.ReplicationState != rmn.Secondary {
+		return fmt.Errorf("replication state %q: %w", vrg.Spec.ReplicationState, ErrSourceVRGNotDemoted)
+	}
+
+	if !mwu.IsManifestWorkObservedAndApplied(mw) {
+		return fmt.Errorf("demotion to Secondary not yet applied: %w", ErrSourceVRGNotDemoted)
+	}
+
+	return nil
+}
+
+func (mwu *MWUtil) DeleteManifestWorksForCluster(clusterName string) error {
+	// VRG
+	err := mwu.deleteManifestWorkWrapper(clusterName, MWTypeVRG)
+	if err != nil {
+		mwu.Log.Error(err, "failed to delete MW for VRG")
+
+		return fmt.Errorf("failed to delete ManifestWork for VRG in namespace %s (%w)", clusterName, err)
+	}
+
+	// The ManifestWork that created a Namespace is intentionally left on the server
+
+	return nil
+}
+
+// DeleteManifestWorksBySelector deletes the ManifestWorks in the cluster namespace matching selector, refusing to
+// delete those not created by Ramen as DeleteManifestWork does. Deletion continues past individual failures, which
+// are returned together as an aggregate error. An empty selector is refused, as it would match every ManifestWork.
+func (mwu *MWUtil) DeleteManifestWorksBySelector(cluster string, selector labels.Selector) error {
+	if selector == nil || selector.Empty() {
+		return fmt.Errorf("refusing to delete ManifestWorks in %s using an empty selector", cluster)
+	}
+
+	mws, err := mwu.listManifestWorks(cluster, selector, func(*ocmworkv1.ManifestWork) bool { return true })
+	if err != nil {
+		return err
+	}
+
+	errs := []error{}
+
+	for i := range mws {
+		if err := mwu.DeleteManifestWork(mws[i].GetName(), cluster); err != nil {
+			errs = append(errs, fmt.Errorf("ManifestWork %s/%s: %w", cluster, mws[i].GetName(), err))
+		}
+	}
+
+	return utilerrors.NewAggregate(errs)
+}
+
+func (mwu *MWUtil) deleteManifestWorkWrapper(fromCluster string, mwType string) error {
+	mwName := mwu.BuildManifestWorkName(mwType)
+
+	return mwu.DeleteManifestWork(mwName, fromCluster)
+}
+
+// DeleteManifestWork deletes the named ManifestWork for the cluster, refusing to do so with
+// ErrManifestWorkNotManaged if it was not created by Ramen
+func (mwu *MWUtil) DeleteManifestWork(mwName, cluster string) error {
+	return mwu.deleteManifestWork(mwName, cluster, false, nil)
+}
+
+// DeleteManifestWorkForce deletes the named ManifestWork for the cluster even if it was not created by Ramen
+func (mwu *MWUtil) DeleteManifestWorkForce(mwName, cluster string) error {
+	return mwu.deleteManifestWork(mwName, cluster, true, nil)
+}
+
+// DeleteManifestWorkIfCondition deletes the named ManifestWork only if pred returns true for it, returning
+// ErrManifestWorkConditionNotMet otherwise. As with DeleteManifestWork, a missing ManifestWork is not an error.
+func (mwu *MWUtil) DeleteManifestWorkIfCondition(mwName, cluster string,
+	pred func(*ocmworkv1.ManifestWork) bool,
+) error {
+	return mwu.deleteManifestWork(mwName, cluster, false, pred)
+}
+
+// DeleteManifestWorkIfApplied deletes the named ManifestWork only if it is in applied state
+func (mwu *MWUtil) DeleteManifestWorkIfApplied(mwName, cluster string) error {
+	return mwu.DeleteManifestWorkIfCondition(mwName, cluster, IsManifestInAppliedState)
+}
+
+// RemoveManifestWorkFinalizer removes ManifestWorkProtectionFinalizer from the named ManifestWork, if present,
+// allowing a deleted ManifestWork to be removed. A missing ManifestWork is not an error.
+func (mwu *MWUtil) RemoveManifestWorkFinalizer(mwName, cluster string) error {
+	if err := mwu.checkClusterDeleteNotPaused(cluster); err != nil {
+		return err
+	}
+
+	mw := &ocmworkv1.ManifestWork{}
+
+	err := mwu.Client.Get(mwu.Ctx,
+		types.NamespacedName{Name: mwName, Namespace: ClusterManifestWorkNamespace(cluster)}, mw)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to get ManifestWork %s/%s: %w", cluster, mwName, err)
+	}
+
+	if !controllerutil.ContainsFinalizer(mw, ManifestWorkProtectionFinalizer) {
+		return nil
+	}
+
+	controllerutil.RemoveFinalizer(mw, ManifestWorkProtectionFinalizer)
+
+	mwu.Log.Info("Removing ManifestWork finalizer", "cluster", cluster, "name", mwName)
+
+	if err := mwu.Client.Update(mwu.Ctx, mw); err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to remove finalizer from ManifestWork %s/%s: %w", cluster, mwName, err)
+	}
+
+	return nil
+}
+
+func (mwu *MWUtil) deleteManifestWork(mwName, cluster string, force bool,
+	pred func(*ocmworkv1.ManifestWork) bool,
+) error {
+	mwu.Log.Info("Delete ManifestWork from", "cluster", cluster, "name", mwName)
+
+	if err := mwu.checkClusterDeleteNotPaused(cluster); err != nil {
+		return err
+	}
+
+	mw := &ocmworkv1.ManifestWork{}
+
+	err := mwu.Client.Get(mwu.Ctx,
+		types.NamespacedName{Name: mwName, Namespace: ClusterManifestWorkNamespace(cluster)}, mw)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to retrieve manifestwork for type: %s. Error: %w", mwName, err)
+	}
+
+	if !force && !IsManifestWorkManagedByRamen(mw) {
+		return fmt.Errorf("refusing to delete ManifestWork %s/%s: %w", cluster, mwName, ErrManifestWorkNotManaged)
+	}
+
+	if pred != nil && !pred(mw) {
+		return fmt.Errorf("not deleting ManifestWork %s/%s: %w", cluster, mwName, ErrManifestWorkConditionNotMet)
+	}
+
+	if controllerutil.ContainsFinalizer(mw, ManifestWorkProtectionFinalizer) {
+		controllerutil.RemoveFinalizer(mw, ManifestWorkProtectionFinalizer)
+
+		if err := mwu.Client.Update(mwu.Ctx, mw); err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to remove finalizer from ManifestWork %s/%s: %w", cluster, mwName, err)
+		}
+	}
+
+	mwu.Log.Info("Deleting ManifestWork", "MW", ManifestWorkSummary(mw))
+
+	err = mwu.Client.Delete(mwu.Ctx, mw)
+	if err != nil && !errors.IsNotFound(err) {
+		mwu.reportEvent(corev1.EventTypeWarning, EventReasonManifestWorkDeleteFailed,
+			fmt.Sprintf("failed to delete ManifestWork %s/%s: %v", cluster, mwName, err))
+
+		return fmt.Errorf("failed to delete MW. Error %w", err)
+	}
+
+	mwu.reportEvent(corev1.EventTypeNormal, EventReasonManifestWorkDeleted,
+		fmt.Sprintf("deleted ManifestWork %s/%s", cluster, mwName))
+
+	return nil
+}
+
+// ManifestWorkRequeueAfter returns a suggested requeue delay for an error returned by an MWUtil operation, based on
+// the ManifestWork state it reports, or zero if there is no suggestion and the caller's default backoff applies
+func ManifestWorkRequeueAfter(err error) time.Duration {
+	switch {
+	case err == nil:
+		return 0
+	case errorswrapper.Is(err, ErrClusterUnreachable),
+		errorswrapper.Is(err, ErrClusterNamespaceMissing),
+		errorswrapper.Is(err, ErrClusterReconciliationPaused):
+		return ClusterUnreachableRequeueDelay
+	case errorswrapper.Is(err, ErrManifestWorkMigrationPending),
+		errorswrapper.Is(err, ErrManifestWorkRelocationPending),
+		errorswrapper.Is(err, ErrClusterDrainPending),
+		errorswrapper.Is(err, ErrSourceVRGNotDemoted),
+		errorswrapper.Is(err, ErrManifestWorkTerminating),
+		errorswrapper.Is(err, ErrPlacementDecisionPending):
+		return ManifestWorkPendingRequeueDelay
+	default:
+		return 0
+	}
+}
+
+// IsRetryableManifestWorkError reports whether an error returned by an MWUtil operation is transient, such that
+// retrying the operation later may succeed. It is true for the pending states ManifestWorkRequeueAfter suggests a
+// delay for, conflicts, timeouts and transient server errors, and false for invalid or forbidden requests and for
+// errors that require the ManifestWork or its inputs to change.
+func IsRetryableManifestWorkError(err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case errors.IsInvalid(err), errors.IsBadRequest(err), errors.IsForbidden(err), errors.IsUnauthorized(err),
+		errors.IsMethodNotSupported(err), errors.IsNotAcceptable(err), errors.IsRequestEntityTooLargeError(err):
+		return false
+	case ManifestWorkRequeueAfter(err) > 0:
+		return true
+	case errorswrapper.Is(err, ErrManifestWorkNotManaged),
+		errorswrapper.Is(err, ErrManifestWorkTooLarge),
+		errorswrapper.Is(err, ErrS3ProfileNotDefined),
+		errorswrapper.Is(err, ErrVRGManifestNotFound),
+		errorswrapper.Is(err, ErrMultiplePrimaryClusters):
+		return false
+	case errors.IsConflict(err), errors.IsAlreadyExists(err), errors.IsTimeout(err), errors.IsServerTimeout(err),
+		errors.IsTooManyRequests(err), errors.IsInternalError(err), errors.IsServiceUnavailable(err),
+		errors.IsUnexpectedServerError(err):
+		return true
+	default:
+		return errorswrapper.Is(err, context.DeadlineExceeded)
+	}
+}
+
+// isClusterUnreachableCause returns true for errors that indicate the hub could not reach the cluster namespace
+// objects in time, as opposed to the objects being absent. A forbidden error is not, as it is due to the RBAC of the
+// hub itself, and is returned as is.
+func isClusterUnreachableCause(err error) bool {
+	return errors.IsTimeout(err) || errors.IsServerTimeout(err)
+}
+
+// clusterUnreachableError is ErrClusterUnreachable, wrapping the API error it is due to, so that callers may inspect
+// both
+type clusterUnreachableError struct {
+	msg string
+	err error
+}
+
+func (e *clusterUnreachableError) Error() string {
+	return fmt.Sprintf("%s (%v): %v", e.msg, e.err, ErrClusterUnreachable)
+}
+
+func (e *clusterUnreachableError) Unwrap() error {
+	return e.err
+}
+
+func (e *clusterUnreachableError) Is(target error) bool {
+	return target == ErrClusterUnreachable
+}
 
-	return nil, nil
+// manifestWorkSnapshot is the serialized form of a ManifestWork captured by CaptureManifestWorkSnapshot
+type manifestWorkSnapshot struct {
+	Name        string                     `json:"name"`
+	Namespace   string                     `json:"namespace"`
+	Labels      map[string]string          `json:"labels,omitempty"`
+	Annotations map[string]string          `json:"annotations,omitempty"`
+	Spec        ocmworkv1.ManifestWorkSpec `json:"spec"`
 }
 
-func (mwu *MWUtil) GetDrClusterManifestWork(clusterName string) (*ocmworkv1.ManifestWork, error) {
-	mw, err := mwu.FindManifestWork(DrClusterManifestWorkName, clusterName)
+// CaptureManifestWorkSnapshot returns a serialized snapshot of the named ManifestWork, that may later be passed to
+// RestoreManifestWorkSnapshot to revert the ManifestWork to its current spec
+func (mwu *MWUtil) CaptureManifestWorkSnapshot(mwName, cluster string) ([]byte, error) {
+	mw, err := mwu.FindManifestWork(mwName, cluster)
 	if err != nil {
-		if errors.IsNotFound(err) {
-			return nil, nil
-		}
-
 		return nil, err
 	}
 
-	return mw, nil
+	snapshot, err := json.Marshal(manifestWorkSnapshot{
+		Name:        mw.GetName(),
+		Namespace:   mw.GetNamespace(),
+		Labels:      mw.GetLabels(),
+		Annotations: mw.GetAnnotations(),
+		Spec:        mw.Spec,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal ManifestWork %s/%s snapshot: %w", cluster, mwName, err)
+	}
+
+	return snapshot, nil
 }
 
-func (mwu *MWUtil) CreateOrUpdateDrClusterManifestWork(
-	clusterName string,
-	objectsToAppend []interface{}, annotations map[string]string,
-) error {
-	objects := append(
-		[]interface{}{
-			vrgClusterRole,
-			vrgClusterRoleBinding,
-			mModeClusterRole,
-			mModeClusterRoleBinding,
-		},
-		objectsToAppend...,
-	)
+// RestoreManifestWorkSnapshot creates or updates the ManifestWork captured in snapshot, reverting it to the
+// captured spec. The snapshot must have been captured from the passed in cluster.
+func (mwu *MWUtil) RestoreManifestWorkSnapshot(cluster string, snapshot []byte) error {
+	mwSnapshot := manifestWorkSnapshot{}
+	if err := json.Unmarshal(snapshot, &mwSnapshot); err != nil {
+		return fmt.Errorf("failed to unmarshal ManifestWork snapshot: %w", err)
+	}
 
-	manifests := make([]ocmworkv1.Manifest, len(objects))
+	if mwSnapshot.Name == "" || mwSnapshot.Namespace != cluster {
+		return fmt.Errorf("ManifestWork snapshot %s/%s does not match cluster %s",
+			mwSnapshot.Namespace, mwSnapshot.Name, cluster)
+	}
 
-	for i, object := range objects {
-		manifest, err := mwu.GenerateManifest(object)
-		if err != nil {
-			mwu.Log.Error(err, "failed to generate manifest", "object", object)
+	mw := mwu.newManifestWork(mwSnapshot.Name, cluster, mwSnapshot.Labels,
+		mwSnapshot.Spec.Workload.Manifests, mwSnapshot.Annotations)
+	mwSnapshot.Spec.DeepCopyInto(&mw.Spec)
 
-			return err
-		}
+	return mwu.createOrUpdateManifestWork(mw, cluster)
+}
 
-		manifests[i] = *manifest
+// WaitForManifestWorkDeleted waits up to timeout for the named ManifestWork to be removed from the hub, which OCM
+// does only once the work agent has deleted the resources it applied on the managed cluster.
+func (mwu *MWUtil) WaitForManifestWorkDeleted(mwName, cluster string, timeout time.Duration) error {
+	var reader client.Reader = mwu.Client
+	if mwu.APIReader != nil {
+		reader = mwu.APIReader
 	}
 
-	return mwu.createOrUpdateManifestWork(
-		mwu.newManifestWork(
-			DrClusterManifestWorkName,
-			clusterName,
-			map[string]string{},
-			manifests, annotations,
-		),
-		clusterName,
-	)
-}
+	err := wait.PollImmediateWithContext(mwu.Ctx, manifestWorkPollInterval, timeout,
+		func(ctx context.Context) (bool, error) {
+			mw := &ocmworkv1.ManifestWork{}
 
-var (
-	vrgClusterRole = &rbacv1.ClusterRole{
-		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRole", APIVersion: "rbac.authorization.k8s.io/v1"},
-		ObjectMeta: metav1.ObjectMeta{Name: "open-cluster-management:klusterlet-work-sa:agent:volrepgroup-edit"},
-		Rules: []rbacv1.PolicyRule{
-			{
-				APIGroups: []string{"ramendr.openshift.io"},
-				Resources: []string{"volumereplicationgroups"},
-				Verbs:     []string{"create", "get", "list", "update", "delete"},
-			},
-		},
-	}
+			err := reader.Get(ctx, types.NamespacedName{Name: mwName, Namespace: ClusterManifestWorkNamespace(cluster)}, mw)
+			if errors.IsNotFound(err) {
+				return true, nil
+			}
 
-	vrgClusterRoleBinding = &rbacv1.ClusterRoleBinding{
-		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"},
-		ObjectMeta: metav1.ObjectMeta{Name: "open-cluster-management:klusterlet-work-sa:agent:volrepgroup-edit"},
-		Subjects: []rbacv1.Subject{
-			{
-				Kind:      "ServiceAccount",
-				Name:      "klusterlet-work-sa",
-				Namespace: "open-cluster-management-agent",
-			},
-		},
-		RoleRef: rbacv1.RoleRef{
-			APIGroup: "rbac.authorization.k8s.io",
-			Kind:     "ClusterRole",
-			Name:     "open-cluster-management:klusterlet-work-sa:agent:volrepgroup-edit",
-		},
+			return false, err
+		})
+	if err != nil {
+		return fmt.Errorf("waiting for ManifestWork %s/%s deletion: %w", cluster, mwName, err)
 	}
 
-	mModeClusterRole = &rbacv1.ClusterRole{
-		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRole", APIVersion: "rbac.authorization.k8s.io/v1"},
-		ObjectMeta: metav1.ObjectMeta{Name: "open-cluster-management:klusterlet-work-sa:agent:mmode-edit"},
-		Rules: []rbacv1.PolicyRule{
-			{
-				APIGroups: []string{"ramendr.openshift.io"},
-				Resources: []string{"maintenancemodes"},
-				Verbs:     []string{"create", "get", "list", "update", "delete"},
-			},
-		},
-	}
+	return nil
+}
 
-	mModeClusterRoleBinding = &rbacv1.ClusterRoleBinding{
-		TypeMeta:   metav1.TypeMeta{Kind: "ClusterRoleBinding", APIVersion: "rbac.authorization.k8s.io/v1"},
-		ObjectMeta: metav1.ObjectMeta{Name: "open-cluster-management:klusterlet-work-sa:agent:mmode-edit"},
-		Subjects: []rbacv1.Subject{
-			{
-				Kind:      "ServiceAccount",
-				Name:      "klusterlet-work-sa",
-				Namespace: "open-cluster-management-agent",
-			},
-		},
-		RoleRef: rbacv1.RoleRef{
-			APIGroup: "rbac.authorization.k8s.io",
-			Kind:     "ClusterRole",
-			Name:     "open-cluster-management:klusterlet-work-sa:agent:mmode-edit",
-		},
+// WaitForManifestWorkApplied waits up to timeout for the named ManifestWork to be applied by the work agent, as
+// reported by IsManifestWorkObservedAndApplied for its current generation. A missing ManifestWork is waited on as well.
+func (mwu *MWUtil) WaitForManifestWorkApplied(mwName, cluster string, timeout time.Duration) error {
+	var reader client.Reader = mwu.Client
+	if mwu.APIReader != nil {
+		reader = mwu.APIReader
 	}
-)
 
-func (mwu *MWUtil) GenerateManifest(obj interface{}) (*ocmworkv1.Manifest, error) {
-	objJSON, err := json.Marshal(obj)
+	err := wait.PollImmediateWithContext(mwu.Ctx, manifestWorkPollInterval, timeout,
+		func(ctx context.Context) (bool, error) {
+			mw := &ocmworkv1.ManifestWork{}
+
+			err := reader.Get(ctx, types.NamespacedName{Name: mwName, Namespace: ClusterManifestWorkNamespace(cluster)}, mw)
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+
+			if err != nil {
+				return false, err
+			}
+
+			return mwu.IsManifestWorkObservedAndApplied(mw), nil
+		})
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal %v to JSON, error %w", obj, err)
+		return fmt.Errorf("waiting for ManifestWork %s/%s to be applied: %w", cluster, mwName, err)
 	}
 
-	manifest := &ocmworkv1.Manifest{}
-	manifest.RawExtension = runtime.RawExtension{Raw: objJSON}
-
-	return manifest, nil
+	return nil
 }
 
-func (mwu *MWUtil) newManifestWork(name string, mcNamespace string,
-	labels map[string]string, manifests []ocmworkv1.Manifest, annotations map[string]string,
-) *ocmworkv1.ManifestWork {
-	mw := &ocmworkv1.ManifestWork{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: mcNamespace,
-			Labels:    labels,
-		},
-		Spec: ocmworkv1.ManifestWorkSpec{
-			Workload: ocmworkv1.ManifestsTemplate{
-				Manifests: manifests,
-			},
-		},
+// WatchManifestWork streams the named ManifestWork each time it is added or modified, and nil when it is deleted, until
+// ctx is cancelled, at which point the returned channel is closed. The watch is re-established if it ends or fails
+// before then. It requires WatchClient to be set.
+func (mwu *MWUtil) WatchManifestWork(ctx context.Context, mwName, cluster string,
+) (<-chan *ocmworkv1.ManifestWork, error) {
+	if mwu.WatchClient == nil {
+		return nil, fmt.Errorf("watch of ManifestWork %s/%s requires a watch client", cluster, mwName)
 	}
 
-	if annotations != nil {
-		mw.ObjectMeta.Annotations = annotations
+	watcher, err := mwu.watchManifestWork(ctx, mwName, cluster, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch ManifestWork %s/%s: %w", cluster, mwName, err)
 	}
 
-	return mw
-}
+	mws := make(chan *ocmworkv1.ManifestWork)
 
-func (mwu *MWUtil) createOrUpdateManifestWork(
-	mw *ocmworkv1.ManifestWork,
-	managedClusternamespace string,
-) error {
-	foundMW := &ocmworkv1.ManifestWork{}
+	go func() {
+		defer close(mws)
 
-	err := mwu.Client.Get(mwu.Ctx,
-		types.NamespacedName{Name: mw.Name, Namespace: managedClusternamespace},
-		foundMW)
-	if err != nil {
-		if !errors.IsNotFound(err) {
-			return errorswrapper.Wrap(err, fmt.Sprintf("failed to fetch ManifestWork %s", mw.Name))
+		resourceVersion := ""
+
+		for {
+			resourceVersion = mwu.streamManifestWork(ctx, watcher, mws, resourceVersion)
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(manifestWorkRewatchDelay):
+				}
+
+				watcher, err = mwu.watchManifestWork(ctx, mwName, cluster, resourceVersion)
+				if err == nil {
+					break
+				}
+
+				mwu.Log.Info("Failed to re-establish ManifestWork watch", "name", mwName, "namespace", cluster,
+					"error", err)
+
+				resourceVersion = ""
+			}
 		}
+	}()
 
-		// Let DRPC receive notification for any changes to ManifestWork CR created by it.
-		// if err := ctrl.SetControllerReference(d.instance, mw, d.reconciler.Scheme); err != nil {
-		//	return fmt.Errorf("failed to set owner reference to ManifestWork resource (%s/%s) (%v)",
-		//		mw.Name, mw.Namespace, err)
-		// }
+	return mws, nil
+}
 
-		mwu.Log.Info("Creating ManifestWork", "cluster", managedClusternamespace, "MW", mw)
+func (mwu *MWUtil) watchManifestWork(ctx context.Context, mwName, cluster, resourceVersion string,
+) (watch.Interface, error) {
+	return mwu.WatchClient.Watch(ctx, &ocmworkv1.ManifestWorkList{},
+		client.InNamespace(ClusterManifestWorkNamespace(cluster)),
+		client.MatchingFields{"metadata.name": mwName},
+		&client.ListOptions{Raw: &metav1.ListOptions{ResourceVersion: resourceVersion}},
+	)
+}
 
-		return mwu.Client.Create(mwu.Ctx, mw)
-	}
+// streamManifestWork sends the ManifestWorks from watcher to mws until the watch ends, fails or ctx is cancelled,
+// and returns the resource version to resume watching from, or "" if the watch must be restarted afresh
+func (mwu *MWUtil) streamManifestWork(ctx context.Context, watcher watch.Interface,
+	mws chan<- *ocmworkv1.ManifestWork, resourceVersion string,
+) string {
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return resourceVersion
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return resourceVersion
+			}
 
-	if !reflect.DeepEqual(foundMW.Spec, mw.Spec) {
-		mwu.Log.Info("ManifestWork exists.", "name", mw.Name, "namespace", foundMW.Namespace)
+			if event.Type == watch.Error {
+				mwu.Log.Info("ManifestWork watch error", "error", errors.FromObject(event.Object))
 
-		retryErr := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-			var err error
+				return ""
+			}
 
-			err = mwu.Client.Get(mwu.Ctx,
-				types.NamespacedName{Name: mw.Name, Namespace: managedClusternamespace},
-				foundMW)
-			if err != nil {
-				return err
+			mw, ok := event.Object.(*ocmworkv1.ManifestWork)
+			if !ok {
+				continue
 			}
 
-			mw.Spec.DeepCopyInto(&foundMW.Spec)
+			resourceVersion = mw.GetResourceVersion()
 
-			err = mwu.Client.Update(mwu.Ctx, foundMW)
+			var sent *ocmworkv1.ManifestWork
+			if event.Type != watch.Deleted {
+				sent = mw.DeepCopy()
+			}
 
-			return err
-		})
+			select {
+			case mws <- sent:
+			case <-ctx.Done():
+				return resourceVersion
+			}
+		}
+	}
+}
+
+// IsManifestWorkManagedByRamen returns true if the ManifestWork carries the Ramen managed-by label, or any of the
+// annotations Ramen stamps on the ManifestWorks it creates
+func IsManifestWorkManagedByRamen(mw *ocmworkv1.ManifestWork) bool {
+	if IsManifestWorkLabeledManagedByRamen(mw) {
+		return true
+	}
+
+	annotations := mw.GetAnnotations()
 
-		if retryErr != nil {
-			return retryErr
+	for _, key := range []string{
+		DRPCNameAnnotation,
+		DRPCNamespaceAnnotation,
+		DRClusterNameAnnotation,
+		drClusterNameAnnotationLegacy,
+	} {
+		if _, ok := annotations[key]; ok {
+			return true
 		}
 	}
 
-	return nil
+	return false
 }
 
-func (mwu *MWUtil) DeleteManifestWorksForCluster(clusterName string) error {
-	// VRG
-	err := mwu.deleteManifestWorkWrapper(clusterName, MWTypeVRG)
+func GetMetricValueSingle(name string, mfType dto.MetricType) (float64, error) {
+	return GetMetricValueFrom(metrics.Registry, name, mfType)
+}
+
+// GetMetricValueFrom is GetMetricValueSingle against the passed in gatherer, allowing tests to use a registry
+// other than the controller-runtime global one
+func GetMetricValueFrom(reg prometheus.Gatherer, name string, mfType dto.MetricType) (float64, error) {
+	mf, err := getMetricFamilyFromRegistry(reg, name)
 	if err != nil {
-		mwu.Log.Error(err, "failed to delete MW for VRG")
+		return 0.0, fmt.Errorf("GetMetricValueFrom returned error finding MetricFamily: %w", err)
+	}
 
-		return fmt.Errorf("failed to delete ManifestWork for VRG in namespace %s (%w)", clusterName, err)
+	val, err := getMetricValueFromMetricFamilyByType(mf, mfType)
+	if err != nil {
+		return 0.0, fmt.Errorf("GetMetricValueFrom returned error finding Value: %w", err)
 	}
 
-	// The ManifestWork that created a Namespace is intentionally left on the server
+	return val, nil
+}
 
-	return nil
+// MetricDelta returns the change in the value of the named metric, from the controller-runtime registry, across
+// running action. A metric that is not yet registered, or not yet gathered, is read as 0.
+func MetricDelta(name string, mfType dto.MetricType, action func() error) (float64, error) {
+	return MetricDeltaFrom(metrics.Registry, name, mfType, action)
 }
 
-func (mwu *MWUtil) deleteManifestWorkWrapper(fromCluster string, mwType string) error {
-	mwName := mwu.BuildManifestWorkName(mwType)
-	mwNamespace := fromCluster
+// MetricDeltaFrom is MetricDelta against the passed in gatherer
+func MetricDeltaFrom(reg prometheus.Gatherer, name string, mfType dto.MetricType,
+	action func() error,
+) (float64, error) {
+	before, err := getMetricValueOrZero(reg, name, mfType)
+	if err != nil {
+		return 0.0, err
+	}
+
+	if err := action(); err != nil {
+		return 0.0, err
+	}
 
-	return mwu.DeleteManifestWork(mwName, mwNamespace)
+	after, err := getMetricValueOrZero(reg, name, mfType)
+	if err != nil {
+		return 0.0, err
+	}
+
+	return after - before, nil
 }
 
-func (mwu *MWUtil) DeleteManifestWork(mwName, mwNamespace string) error {
-	mwu.Log.Info("Delete ManifestWork from", "namespace", mwNamespace, "name", mwName)
+// CounterTracker reads a counter metric as its increase since a baseline, captured when the tracker is created. A
+// counter that is observed to go backwards is treated as reset, for example by a test reinitializing its registry,
+// and the increase observed before the reset is carried over rather than lost.
+type CounterTracker struct {
+	reg      prometheus.Gatherer
+	name     string
+	baseline float64
+	last     float64
+	offset   float64
+}
 
-	mw := &ocmworkv1.ManifestWork{}
+// NewCounterTracker returns a CounterTracker for the named counter in the controller-runtime registry
+func NewCounterTracker(name string) (*CounterTracker, error) {
+	return NewCounterTrackerFrom(metrics.Registry, name)
+}
 
-	err := mwu.Client.Get(mwu.Ctx, types.NamespacedName{Name: mwName, Namespace: mwNamespace}, mw)
+// NewCounterTrackerFrom is NewCounterTracker against the passed in gatherer. A counter that is not yet registered, or
+// not yet gathered, is read as 0.
+func NewCounterTrackerFrom(reg prometheus.Gatherer, name string) (*CounterTracker, error) {
+	baseline, err := getMetricValueOrZero(reg, name, dto.MetricType_COUNTER)
 	if err != nil {
-		if errors.IsNotFound(err) {
-			return nil
-		}
-
-		return fmt.Errorf("failed to retrieve manifestwork for type: %s. Error: %w", mwName, err)
+		return nil, err
 	}
 
-	mwu.Log.Info("Deleting ManifestWork", "name", mw.Name, "namespace", mwNamespace)
+	return &CounterTracker{reg: reg, name: name, baseline: baseline, last: baseline}, nil
+}
 
-	err = mwu.Client.Delete(mwu.Ctx, mw)
-	if err != nil && !errors.IsNotFound(err) {
-		return fmt.Errorf("failed to delete MW. Error %w", err)
+// Delta returns the increase of the counter since the tracker was created, accounting for any resets observed
+func (ct *CounterTracker) Delta() (float64, error) {
+	val, err := getMetricValueOrZero(ct.reg, ct.name, dto.MetricType_COUNTER)
+	if err != nil {
+		return 0.0, err
 	}
 
-	return nil
+	if val < ct.last {
+		ct.offset += ct.last
+	}
+
+	ct.last = val
+
+	return ct.offset + val - ct.baseline, nil
 }
 
-func GetMetricValueSingle(name string, mfType dto.MetricType) (float64, error) {
-	mf, err := getMetricFamilyFromRegistry(name)
+// RamenMetricPrefix is the name prefix of the metrics Ramen registers
+const RamenMetricPrefix = "ramen_"
+
+// ListRegisteredMetricNames returns the sorted names of the metrics in the controller-runtime registry that start
+// with prefix, such as RamenMetricPrefix, or of all its metrics if prefix is empty
+func ListRegisteredMetricNames(prefix string) ([]string, error) {
+	return ListRegisteredMetricNamesFrom(metrics.Registry, prefix)
+}
+
+// ListRegisteredMetricNamesFrom is ListRegisteredMetricNames against the passed in gatherer
+func ListRegisteredMetricNamesFrom(reg prometheus.Gatherer, prefix string) ([]string, error) {
+	metricsFamilies, err := reg.Gather()
 	if err != nil {
-		return 0.0, fmt.Errorf("GetMetricValueSingle returned error finding MetricFamily: %w", err)
+		return nil, fmt.Errorf("found error during Gather step of ListRegisteredMetricNamesFrom: %w", err)
 	}
 
-	val, err := getMetricValueFromMetricFamilyByType(mf, mfType)
-	if err != nil {
-		return 0.0, fmt.Errorf("GetMetricValueSingle returned error finding Value: %w", err)
+	names := []string{}
+
+	for _, mf := range metricsFamilies {
+		if strings.HasPrefix(mf.GetName(), prefix) {
+			names = append(names, mf.GetName())
+		}
 	}
 
-	return val, nil
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func getMetricValueOrZero(reg prometheus.Gatherer, name string, mfType dto.MetricType) (float64, error) {
+	val, err := GetMetricValueFrom(reg, name, mfType)
+	if errorswrapper.Is(err, ErrNoMetricsRegistered) || errorswrapper.Is(err, ErrMetricNotFound) {
+		return 0.0, nil
+	}
+
+	return val, err
 }
 
-func getMetricFamilyFromRegistry(name string) (*dto.MetricFamily, error) {
-	metricsFamilies, err := metrics.Registry.Gather() // TODO: see if this can be made more generic
+func getMetricFamilyFromRegistry(reg prometheus.Gatherer, name string) (*dto.MetricFamily, error) {
+	metricsFamilies, err := reg.Gather()
 	if err != nil {
 		return nil, fmt.Errorf("found error during Gather step of getMetricFamilyFromRegistry: %w", err)
 	}
 
 	if len(metricsFamilies) == 0 {
-		return nil, fmt.Errorf("couldn't get metricsFamilies from Prometheus Registry")
+		return nil, fmt.Errorf("couldn't find MetricFamily with name %s: %w", name, ErrNoMetricsRegistered)
 	}
 
 	// TODO: find out if there's a better way to search than linear scan
@@ -606,7 +3467,45 @@ func getMetricFamilyFromRegistry(name string) (*dto.MetricFamily, error) {
 		}
 	}
 
-	return nil, fmt.Errorf(fmt.Sprint("couldn't find MetricFamily with name", name))
+	return nil, fmt.Errorf("couldn't find MetricFamily with name %s: %w", name, ErrMetricNotFound)
+}
+
+// GetMetricValueWithLabels returns the value of the metric, from the controller-runtime registry, whose labels
+// include all the passed in label name/value pairs
+func GetMetricValueWithLabels(name string, mfType dto.MetricType, labels map[string]string) (float64, error) {
+	mf, err := getMetricFamilyFromRegistry(metrics.Registry, name)
+	if err != nil {
+		return 0.0, fmt.Errorf("GetMetricValueWithLabels returned error finding MetricFamily: %w", err)
+	}
+
+	if *mf.Type != mfType {
+		return 0.0, fmt.Errorf("GetMetricValueWithLabels passed invalid type. Wanted %s, got %s",
+			mfType.String(), mf.Type.String())
+	}
+
+	for _, metric := range mf.Metric {
+		if metricLabelsMatch(metric, labels) {
+			return getMetricValueByType(metric, mfType)
+		}
+	}
+
+	return 0.0, fmt.Errorf("couldn't find Metric %s with labels %v", name, labels)
+}
+
+func metricLabelsMatch(metric *dto.Metric, labels map[string]string) bool {
+	matched := 0
+
+	for _, labelPair := range metric.Label {
+		if value, ok := labels[labelPair.GetName()]; ok {
+			if value != labelPair.GetValue() {
+				return false
+			}
+
+			matched++
+		}
+	}
+
+	return matched == len(labels)
 }
 
 func getMetricValueFromMetricFamilyByType(mf *dto.MetricFamily, mfType dto.MetricType) (float64, error) {
@@ -619,14 +3518,18 @@ func getMetricValueFromMetricFamilyByType(mf *dto.MetricFamily, mfType dto.Metri
 		return 0.0, fmt.Errorf("getMetricValueFromMetricFamilyByType only supports Metric length=1")
 	}
 
+	return getMetricValueByType(mf.Metric[0], mfType)
+}
+
+func getMetricValueByType(metric *dto.Metric, mfType dto.MetricType) (float64, error) {
 	switch mfType {
 	case dto.MetricType_COUNTER:
-		return *mf.Metric[0].Counter.Value, nil
+		return *metric.Counter.Value, nil
 	case dto.MetricType_GAUGE:
-		return *mf.Metric[0].Gauge.Value, nil
+		return *metric.Gauge.Value, nil
 	case dto.MetricType_HISTOGRAM:
 		// Count is more useful for testing over Sum; get Sum elsewhere if needed
-		return float64(*mf.Metric[0].Histogram.SampleCount), nil
+		return float64(*metric.Histogram.SampleCount), nil
 	case dto.MetricType_GAUGE_HISTOGRAM:
 		fallthrough
 	case dto.MetricType_SUMMARY:
@@ -634,7 +3537,7 @@ func getMetricValueFromMetricFamilyByType(mf *dto.MetricFamily, mfType dto.Metri
 	case dto.MetricType_UNTYPED:
 		fallthrough
 	default:
-		return 0.0, fmt.Errorf("getMetricValueFromMetricFamilyByType doesn't support type %s yet. Implement this",
-			string(mfType))
+		return 0.0, fmt.Errorf("getMetricValueByType doesn't support type %s yet. Implement this",
+			mfType.String())
 	}
 }
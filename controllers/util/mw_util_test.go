@@ -4,15 +4,34 @@
 package util_test
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	ocmworkv1 "github.com/open-cluster-management/api/work/v1"
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
 	rmnutil "github.com/ramendr/ramen/controllers/util"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 )
 
@@ -290,3 +309,2807 @@ var _ = Describe("IsManifestInAppliedState", func() {
 		})
 	})
 })
+
+var _ = Describe("DeleteManifestWork", func() {
+	const clusterName = "mw-delete-cluster"
+
+	var mwu *rmnutil.MWUtil
+
+	newForeignMW := func(name string) *ocmworkv1.ManifestWork {
+		mw := &ocmworkv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: clusterName},
+		}
+		Expect(k8sClient.Create(context.TODO(), mw)).To(Succeed())
+
+		return mw
+	}
+
+	BeforeEach(func() {
+		createClusterNamespace(clusterName)
+
+		mwu = newTestMWUtil()
+	})
+
+	It("refuses to delete a ManifestWork not created by Ramen", func() {
+		mw := newForeignMW("foreign-mw")
+
+		err := mwu.DeleteManifestWork(mw.Name, mw.Namespace)
+		Expect(errors.Is(err, rmnutil.ErrManifestWorkNotManaged)).To(BeTrue())
+		Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(mw), mw)).To(Succeed())
+
+		Expect(mwu.DeleteManifestWorkForce(mw.Name, mw.Namespace)).To(Succeed())
+	})
+
+	It("deletes a ManifestWork only when its condition is met", func() {
+		Expect(mwu.CreateOrUpdateNamespaceManifest("conditional", "conditional-ns", clusterName,
+			nil, nil, nil)).To(Succeed())
+
+		mwName := rmnutil.ManifestWorkName("conditional", "conditional-ns", rmnutil.MWTypeNS)
+
+		err := mwu.DeleteManifestWorkIfApplied(mwName, clusterName)
+		Expect(errors.Is(err, rmnutil.ErrManifestWorkConditionNotMet)).To(BeTrue())
+
+		_, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(mwu.DeleteManifestWorkIfCondition(mwName, clusterName, func(*ocmworkv1.ManifestWork) bool {
+			return true
+		})).To(Succeed())
+
+		_, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("deletes a ManifestWork created by Ramen", func() {
+		Expect(mwu.CreateOrUpdateNamespaceManifest("delete", "delete-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		mwName := rmnutil.ManifestWorkName("delete", "delete-ns", rmnutil.MWTypeNS)
+		Expect(mwu.DeleteManifestWork(mwName, clusterName)).To(Succeed())
+	})
+})
+
+var _ = Describe("FindManifestWorkCondition", func() {
+	mw := &ocmworkv1.ManifestWork{
+		Status: ocmworkv1.ManifestWorkStatus{
+			Conditions: []metav1.Condition{
+				{
+					Type:    ocmworkv1.WorkApplied,
+					Status:  metav1.ConditionTrue,
+					Reason:  "AppliedManifestWorkComplete",
+					Message: "Apply manifest work complete",
+				},
+				{
+					Type:    ocmworkv1.WorkDegraded,
+					Status:  metav1.ConditionTrue,
+					Reason:  "ResourceDegraded",
+					Message: "Resource is degraded",
+				},
+			},
+		},
+	}
+
+	It("returns the condition of the requested type", func() {
+		condition := rmnutil.FindManifestWorkCondition(mw, ocmworkv1.WorkDegraded)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Reason).To(Equal("ResourceDegraded"))
+		Expect(condition.Message).To(Equal("Resource is degraded"))
+	})
+
+	It("returns nil for an absent condition", func() {
+		Expect(rmnutil.FindManifestWorkCondition(mw, ocmworkv1.WorkAvailable)).To(BeNil())
+	})
+})
+
+var _ = Describe("CreateOrUpdateNamespaceManifest", func() {
+	const clusterName = "mw-namespace-cluster"
+
+	It("sets the passed in labels and annotations on the Namespace", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		nsLabels := map[string]string{"pod-security.kubernetes.io/enforce": "privileged"}
+		nsAnnotations := map[string]string{"cost-center": "dr"}
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("labels", "labels-ns", clusterName, nil,
+			nsLabels, nsAnnotations)).To(Succeed())
+
+		mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName("labels", "labels-ns", rmnutil.MWTypeNS), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.Spec.Workload.Manifests).To(HaveLen(1))
+
+		namespace := &corev1.Namespace{}
+		Expect(json.Unmarshal(mw.Spec.Workload.Manifests[0].Raw, namespace)).To(Succeed())
+		Expect(namespace.Name).To(Equal("labels-ns"))
+		Expect(namespace.Labels).To(Equal(nsLabels))
+		Expect(namespace.Annotations).To(Equal(nsAnnotations))
+	})
+})
+
+var _ = Describe("CreateOrUpdate of a terminating ManifestWork", func() {
+	const (
+		clusterName = "mw-terminating-cluster"
+		finalizer   = "test.ramendr.openshift.io/hold"
+	)
+
+	It("returns ErrManifestWorkTerminating instead of updating it", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("term", "term-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName("term", "term-ns", rmnutil.MWTypeNS), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Hold the ManifestWork in terminating state, as OCM does using its own finalizer
+		mw.SetFinalizers([]string{finalizer})
+		Expect(k8sClient.Update(context.TODO(), mw)).To(Succeed())
+		Expect(k8sClient.Delete(context.TODO(), mw)).To(Succeed())
+
+		err = mwu.CreateOrUpdateNamespaceManifest("term", "term-ns", clusterName, nil,
+			map[string]string{"changed": "true"}, nil)
+		Expect(errors.Is(err, rmnutil.ErrManifestWorkTerminating)).To(BeTrue())
+
+		Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(mw), mw)).To(Succeed())
+		mw.SetFinalizers(nil)
+		Expect(k8sClient.Update(context.TODO(), mw)).To(Succeed())
+	})
+})
+
+var _ = Describe("ManifestWork server-side apply", func() {
+	const clusterName = "mw-ssa-cluster"
+
+	It("applies as the create or update does, with the protection finalizer and terminating check", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil(func(m *rmnutil.MWUtil) {
+			m.ServerSideApply = true
+			m.ProtectionFinalizer = true
+		})
+		mwName := rmnutil.ManifestWorkName("ssa", "ssa-ns", rmnutil.MWTypeNS)
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("ssa", "ssa-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		mw, err := mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.GetFinalizers()).To(ContainElement(rmnutil.ManifestWorkProtectionFinalizer))
+		Expect(mw.GetManagedFields()).To(ContainElement(
+			HaveField("Manager", Equal(rmnutil.MWFieldManager))))
+
+		resourceVersion := mw.GetResourceVersion()
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("ssa", "ssa-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		mw, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.GetResourceVersion()).To(Equal(resourceVersion))
+
+		Expect(k8sClient.Delete(context.TODO(), mw)).To(Succeed())
+
+		err = mwu.CreateOrUpdateNamespaceManifest("ssa", "ssa-ns", clusterName, nil,
+			map[string]string{"changed": "true"}, nil)
+		Expect(errors.Is(err, rmnutil.ErrManifestWorkTerminating)).To(BeTrue())
+
+		Expect(mwu.RemoveManifestWorkFinalizer(mwName, clusterName)).To(Succeed())
+		Expect(mwu.WaitForManifestWorkDeleted(mwName, clusterName, 5*time.Second)).To(Succeed())
+	})
+})
+
+var _ = Describe("ManifestWorkAppliedProgress", func() {
+	mwu := &rmnutil.MWUtil{}
+
+	manifestCondition := func(ordinal int32, status metav1.ConditionStatus) ocmworkv1.ManifestCondition {
+		return ocmworkv1.ManifestCondition{
+			ResourceMeta: ocmworkv1.ManifestResourceMeta{Ordinal: ordinal},
+			Conditions: []metav1.Condition{
+				{Type: string(ocmworkv1.ManifestApplied), Status: status},
+			},
+		}
+	}
+
+	mw := &ocmworkv1.ManifestWork{
+		Spec: ocmworkv1.ManifestWorkSpec{
+			Workload: ocmworkv1.ManifestsTemplate{
+				Manifests: make([]ocmworkv1.Manifest, 3),
+			},
+		},
+	}
+
+	It("reports none applied when status is not populated", func() {
+		applied, total := mwu.ManifestWorkAppliedProgress(mw)
+		Expect(applied).To(Equal(0))
+		Expect(total).To(Equal(3))
+	})
+
+	It("counts manifests with a true Applied condition", func() {
+		progressMW := mw.DeepCopy()
+		progressMW.Status.ResourceStatus.Manifests = []ocmworkv1.ManifestCondition{
+			manifestCondition(0, metav1.ConditionTrue),
+			manifestCondition(1, metav1.ConditionFalse),
+			manifestCondition(2, metav1.ConditionTrue),
+		}
+
+		applied, total := mwu.ManifestWorkAppliedProgress(progressMW)
+		Expect(applied).To(Equal(2))
+		Expect(total).To(Equal(3))
+	})
+})
+
+var _ = Describe("GenerateManifest", func() {
+	mwu := &rmnutil.MWUtil{}
+
+	recipeGVK := schema.GroupVersionKind{Group: "ramendr.openshift.io", Version: "v1alpha1", Kind: "Recipe"}
+
+	newRecipe := func() *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(recipeGVK)
+		u.SetName("recipe")
+		u.SetNamespace("app-ns")
+		Expect(unstructured.SetNestedField(u.Object, "deployment-recipe", "spec", "appType")).To(Succeed())
+
+		return u
+	}
+
+	It("generates a manifest from an unstructured CRD instance", func() {
+		for _, obj := range []interface{}{newRecipe(), *newRecipe()} {
+			manifest, err := mwu.GenerateManifest(obj)
+			Expect(err).NotTo(HaveOccurred())
+
+			rawObject, err := rmnutil.GetRawExtension([]ocmworkv1.Manifest{*manifest}, recipeGVK)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(rawObject).NotTo(BeNil())
+
+			recipe := &unstructured.Unstructured{}
+			Expect(recipe.UnmarshalJSON(rawObject.Raw)).To(Succeed())
+			Expect(recipe.GetName()).To(Equal("recipe"))
+			Expect(recipe.GetNamespace()).To(Equal("app-ns"))
+
+			appType, found, err := unstructured.NestedString(recipe.Object, "spec", "appType")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(appType).To(Equal("deployment-recipe"))
+		}
+	})
+
+	It("rejects an unstructured object without apiVersion or kind", func() {
+		u := newRecipe()
+		u.SetKind("")
+
+		_, err := mwu.GenerateManifest(u)
+		Expect(err).To(HaveOccurred())
+
+		_, err = mwu.GenerateManifest(&unstructured.Unstructured{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("rejects a typed object of a kind Ramen does not ship until registered", func() {
+		secret := &corev1.Secret{
+			TypeMeta:   metav1.TypeMeta{Kind: "Secret", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "secret", Namespace: "app-ns"},
+		}
+
+		_, err := mwu.GenerateManifest(secret)
+		Expect(errors.Is(err, rmnutil.ErrUnexpectedManifestKind)).To(BeTrue())
+
+		_, err = mwu.GenerateManifest(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "untyped"}})
+		Expect(errors.Is(err, rmnutil.ErrUnexpectedManifestKind)).To(BeTrue())
+
+		rmnutil.RegisterManifestGVKs(corev1.SchemeGroupVersion.WithKind("Secret"))
+
+		_, err = mwu.GenerateManifest(secret)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("generates a clean manifest from an object fetched from the API server", func() {
+		configMap := &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "sanitize-config", Namespace: "default"},
+			Data:       map[string]string{"key": "value"},
+		}
+		Expect(k8sClient.Create(context.TODO(), configMap)).To(Succeed())
+
+		fetched := &corev1.ConfigMap{}
+		Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(configMap), fetched)).To(Succeed())
+		Expect(fetched.GetManagedFields()).NotTo(BeEmpty())
+		fetched.TypeMeta = configMap.TypeMeta
+
+		manifest, err := mwu.GenerateManifest(fetched)
+		Expect(err).NotTo(HaveOccurred())
+
+		object := map[string]interface{}{}
+		Expect(json.Unmarshal(manifest.Raw, &object)).To(Succeed())
+		Expect(object).To(HaveKey("metadata"))
+		Expect(object["metadata"]).NotTo(HaveKey("managedFields"))
+		Expect(object["metadata"]).NotTo(HaveKey("resourceVersion"))
+		Expect(object["metadata"]).NotTo(HaveKey("uid"))
+		Expect(object["metadata"]).NotTo(HaveKey("creationTimestamp"))
+		Expect(object["metadata"]).To(HaveKeyWithValue("name", "sanitize-config"))
+		Expect(object["data"]).To(HaveKeyWithValue("key", "value"))
+	})
+
+	It("removes the status unless asked to preserve it", func() {
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "sanitize", Namespace: "sanitize-ns"},
+			Spec:       validVRGSpec(),
+			Status:     rmn.VolumeReplicationGroupStatus{State: rmn.PrimaryState},
+		}
+
+		manifest, err := mwu.GenerateManifest(vrg)
+		Expect(err).NotTo(HaveOccurred())
+
+		object := map[string]interface{}{}
+		Expect(json.Unmarshal(manifest.Raw, &object)).To(Succeed())
+		Expect(object).NotTo(HaveKey("status"))
+		Expect(object).To(HaveKey("spec"))
+
+		preservingMWU := &rmnutil.MWUtil{PreserveManifestStatus: true}
+
+		manifest, err = preservingMWU.GenerateManifest(vrg)
+		Expect(err).NotTo(HaveOccurred())
+
+		manifestVRG := &rmn.VolumeReplicationGroup{}
+		Expect(json.Unmarshal(manifest.Raw, manifestVRG)).To(Succeed())
+		Expect(manifestVRG.Status.State).To(Equal(rmn.PrimaryState))
+	})
+
+	It("stamps the hub name only when set", func() {
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "sanitize", Namespace: "sanitize-ns"},
+			Spec:       validVRGSpec(),
+		}
+
+		manifest, err := mwu.GenerateManifest(vrg)
+		Expect(err).NotTo(HaveOccurred())
+
+		manifestVRG := &rmn.VolumeReplicationGroup{}
+		Expect(json.Unmarshal(manifest.Raw, manifestVRG)).To(Succeed())
+		Expect(manifestVRG.GetAnnotations()).NotTo(HaveKey(rmnutil.HubNameAnnotation))
+
+		hubMWU := &rmnutil.MWUtil{HubName: "hub-1"}
+
+		manifest, err = hubMWU.GenerateManifest(vrg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(json.Unmarshal(manifest.Raw, manifestVRG)).To(Succeed())
+		Expect(manifestVRG.GetAnnotations()).To(HaveKeyWithValue(rmnutil.HubNameAnnotation, "hub-1"))
+		Expect(vrg.GetAnnotations()).To(BeEmpty())
+
+		ns := &unstructured.Unstructured{}
+		ns.SetAPIVersion("v1")
+		ns.SetKind("Namespace")
+		ns.SetName("sanitize-ns")
+
+		manifest, err = hubMWU.GenerateManifest(ns)
+		Expect(err).NotTo(HaveOccurred())
+
+		manifestNS := &corev1.Namespace{}
+		Expect(json.Unmarshal(manifest.Raw, manifestNS)).To(Succeed())
+		Expect(manifestNS.GetAnnotations()).To(HaveKeyWithValue(rmnutil.HubNameAnnotation, "hub-1"))
+		Expect(ns.GetAnnotations()).To(BeEmpty())
+	})
+})
+
+func validVRGSpec() rmn.VolumeReplicationGroupSpec {
+	return rmn.VolumeReplicationGroupSpec{
+		PVCSelector:      metav1.LabelSelector{MatchLabels: map[string]string{"appclass": "gold"}},
+		ReplicationState: rmn.Primary,
+		S3Profiles:       []string{"s3-profile"},
+		Sync:             &rmn.VRGSyncSpec{},
+	}
+}
+
+var _ = Describe("CreateOrUpdateVRGManifestWork force resync", func() {
+	const clusterName = "mw-resync-cluster"
+
+	var mwu *rmnutil.MWUtil
+
+	vrg := rmn.VolumeReplicationGroup{
+		TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "resync", Namespace: "resync-ns"},
+		Spec:       validVRGSpec(),
+	}
+
+	forceResyncValue := func() string {
+		mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName("resync", "resync-ns", rmnutil.MWTypeVRG), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		mwVRG, err := rmnutil.ExtractVRGFromManifestWork(mw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mwVRG).NotTo(BeNil())
+
+		return mwVRG.GetAnnotations()[rmnutil.ForceResyncAnnotation]
+	}
+
+	BeforeEach(func() {
+		createClusterNamespace(clusterName)
+
+		mwu = newTestMWUtil()
+	})
+
+	It("stamps a new value only when forced and carries it forward otherwise", func() {
+		Expect(mwu.CreateOrUpdateVRGManifestWork("resync", "resync-ns", clusterName, vrg, nil, false)).To(Succeed())
+		Expect(forceResyncValue()).To(BeEmpty())
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork("resync", "resync-ns", clusterName, vrg, nil, true)).To(Succeed())
+		firstValue := forceResyncValue()
+		Expect(firstValue).NotTo(BeEmpty())
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork("resync", "resync-ns", clusterName, vrg, nil, false)).To(Succeed())
+		Expect(forceResyncValue()).To(Equal(firstValue))
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork("resync", "resync-ns", clusterName, vrg, nil, true)).To(Succeed())
+		Expect(forceResyncValue()).NotTo(Equal(firstValue))
+		Expect(vrg.GetAnnotations()).To(BeEmpty())
+	})
+})
+
+var _ = Describe("ReapplyVRGManifestWork", func() {
+	const clusterName = "mw-reapply-cluster"
+
+	vrg := rmn.VolumeReplicationGroup{
+		TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "reapply", Namespace: "reapply-ns"},
+		Spec:       validVRGSpec(),
+	}
+
+	It("updates the ManifestWork once and does not update it on subsequent reconciles", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+		mwName := rmnutil.ManifestWorkName("reapply", "reapply-ns", rmnutil.MWTypeVRG)
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork("reapply", "reapply-ns", clusterName, vrg, nil, false)).To(Succeed())
+		mw, err := mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		generation := mw.GetGeneration()
+
+		Expect(mwu.ReapplyVRGManifestWork("reapply", "reapply-ns", clusterName)).To(Succeed())
+		mw, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.GetGeneration()).To(BeNumerically(">", generation))
+		generation = mw.GetGeneration()
+
+		mwVRG, err := rmnutil.ExtractVRGFromManifestWork(mw)
+		Expect(err).NotTo(HaveOccurred())
+		reapplyValue := mwVRG.GetAnnotations()[rmnutil.ReapplyAnnotation]
+		Expect(reapplyValue).NotTo(BeEmpty())
+		Expect(mwVRG.Spec.ReplicationState).To(Equal(rmn.Primary))
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork("reapply", "reapply-ns", clusterName, vrg, nil, false)).To(Succeed())
+		mw, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.GetGeneration()).To(Equal(generation))
+
+		mwVRG, err = rmnutil.ExtractVRGFromManifestWork(mw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mwVRG.GetAnnotations()).To(HaveKeyWithValue(rmnutil.ReapplyAnnotation, reapplyValue))
+	})
+})
+
+var _ = Describe("MigrateManifestWorkName", func() {
+	const clusterName = "mw-migrate-cluster"
+
+	oldName := func(name, namespace, mwType string) string {
+		return fmt.Sprintf("%s-%s-%s-old-mw", name, namespace, mwType)
+	}
+
+	It("moves a ManifestWork to its new name once applied", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil(func(m *rmnutil.MWUtil) {
+			m.InstName = "migrate"
+			m.TargetNamespace = "migrate-ns"
+		})
+
+		oldMW := &ocmworkv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        oldName("migrate", "migrate-ns", rmnutil.MWTypeNS),
+				Namespace:   clusterName,
+				Annotations: map[string]string{rmnutil.DRPCNameAnnotation: "migrate"},
+			},
+		}
+		Expect(k8sClient.Create(context.TODO(), oldMW)).To(Succeed())
+
+		err := mwu.MigrateManifestWorkName(clusterName, rmnutil.MWTypeNS, oldName, rmnutil.ManifestWorkName)
+		Expect(errors.Is(err, rmnutil.ErrManifestWorkMigrationPending)).To(BeTrue())
+
+		newMW, err := mwu.FindManifestWorkByType(rmnutil.MWTypeNS, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(newMW.GetAnnotations()).To(HaveKeyWithValue(rmnutil.DRPCNameAnnotation, "migrate"))
+
+		newMW.Status.Conditions = []metav1.Condition{
+			{
+				Type:               ocmworkv1.WorkApplied,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Applied",
+				LastTransitionTime: metav1.Now(),
+			},
+			{
+				Type:               ocmworkv1.WorkAvailable,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Available",
+				LastTransitionTime: metav1.Now(),
+			},
+		}
+		Expect(k8sClient.Status().Update(context.TODO(), newMW)).To(Succeed())
+
+		Expect(mwu.MigrateManifestWorkName(clusterName, rmnutil.MWTypeNS, oldName,
+			rmnutil.ManifestWorkName)).To(Succeed())
+		Expect(k8serrors.IsNotFound(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(oldMW), oldMW))).To(BeTrue())
+
+		// Idempotent once migrated
+		Expect(mwu.MigrateManifestWorkName(clusterName, rmnutil.MWTypeNS, oldName,
+			rmnutil.ManifestWorkName)).To(Succeed())
+	})
+})
+
+var _ = Describe("ManifestWork events", func() {
+	const clusterName = "mw-events-cluster"
+
+	It("reports events on the passed in object when a recorder is set", func() {
+		createClusterNamespace(clusterName)
+
+		fakeRecorder := record.NewFakeRecorder(10)
+		mwu := newTestMWUtil(func(m *rmnutil.MWUtil) {
+			m.EventRecorder = rmnutil.NewEventReporter(fakeRecorder)
+			m.EventObject = &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "owner-ns"}}
+		})
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("events", "events-ns", clusterName, nil, nil, nil)).To(Succeed())
+		Eventually(fakeRecorder.Events).Should(Receive(ContainSubstring(rmnutil.EventReasonManifestWorkCreated)))
+
+		Expect(mwu.DeleteManifestWork(rmnutil.ManifestWorkName("events", "events-ns", rmnutil.MWTypeNS),
+			clusterName)).To(Succeed())
+		Eventually(fakeRecorder.Events).Should(Receive(ContainSubstring(rmnutil.EventReasonManifestWorkDeleted)))
+	})
+})
+
+var _ = Describe("ManifestWork size limit", func() {
+	It("refuses ManifestWorks whose manifests exceed the limit", func() {
+		mwu := newTestMWUtil()
+
+		configMap := &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "large", Namespace: "large-ns"},
+			Data:       map[string]string{"data": strings.Repeat("x", rmnutil.ManifestWorkSizeLimit)},
+		}
+
+		err := mwu.CreateOrUpdateDrClusterManifestWork("mw-size-cluster", []interface{}{configMap}, nil)
+		Expect(errors.Is(err, rmnutil.ErrManifestWorkTooLarge)).To(BeTrue())
+	})
+})
+
+var _ = Describe("ManifestWork operation ID", func() {
+	const clusterName = "mw-operation-id-cluster"
+
+	BeforeEach(func() {
+		createClusterNamespace(clusterName)
+	})
+
+	It("stamps the same generated operation ID on every ManifestWork", func() {
+		mwu := newTestMWUtil()
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("opid1", "opid1-ns", clusterName, nil, nil, nil)).To(Succeed())
+		Expect(mwu.CreateOrUpdateNamespaceManifest("opid2", "opid2-ns", clusterName, nil, nil, nil)).To(Succeed())
+		Expect(mwu.OperationID).NotTo(BeEmpty())
+
+		for _, name := range []string{"opid1", "opid2"} {
+			mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName(name, name+"-ns", rmnutil.MWTypeNS), clusterName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mw.GetAnnotations()).To(HaveKeyWithValue(rmnutil.OperationIDAnnotation, mwu.OperationID))
+		}
+	})
+
+	It("stamps the configured operation ID", func() {
+		mwu := newTestMWUtil(func(m *rmnutil.MWUtil) { m.OperationID = "failover-1234" })
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("opid3", "opid3-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName("opid3", "opid3-ns", rmnutil.MWTypeNS), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.GetAnnotations()).To(HaveKeyWithValue(rmnutil.OperationIDAnnotation, "failover-1234"))
+	})
+})
+
+var _ = Describe("SetVRGActionInManifestWork", func() {
+	const clusterName = "mw-vrg-action-cluster"
+
+	var mwu *rmnutil.MWUtil
+
+	BeforeEach(func() {
+		createClusterNamespace(clusterName)
+
+		mwu = newTestMWUtil()
+	})
+
+	It("changes only the replication state of the VRG", func() {
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "action", Namespace: "action-ns"},
+			Spec:       validVRGSpec(),
+		}
+		vrg.Spec.Action = rmn.VRGActionFailover
+		Expect(mwu.CreateOrUpdateVRGManifestWork("action", "action-ns", clusterName, vrg, nil, false)).To(Succeed())
+
+		Expect(mwu.SetVRGActionInManifestWork("action", "action-ns", clusterName,
+			string(rmn.Secondary))).To(Succeed())
+
+		mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName("action", "action-ns", rmnutil.MWTypeVRG), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		mwVRG, err := rmnutil.ExtractVRGFromManifestWork(mw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mwVRG.Spec.ReplicationState).To(Equal(rmn.Secondary))
+		Expect(mwVRG.Spec.Action).To(Equal(rmn.VRGActionFailover))
+		Expect(mwVRG.Spec.S3Profiles).To(Equal([]string{"s3-profile"}))
+	})
+
+	It("fails for an invalid replication state", func() {
+		Expect(mwu.SetVRGActionInManifestWork("action", "action-ns", clusterName, "tertiary")).NotTo(Succeed())
+	})
+
+	It("fails when the ManifestWork is absent", func() {
+		err := mwu.SetVRGActionInManifestWork("absent", "absent-ns", clusterName, string(rmn.Primary))
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("fails when the ManifestWork has no VRG manifest", func() {
+		Expect(mwu.CreateOrUpdateNamespaceManifest("novrg", "novrg-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		nsMWName := rmnutil.ManifestWorkName("novrg", "novrg-ns", rmnutil.MWTypeNS)
+		nsMW, err := mwu.FindManifestWork(nsMWName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		vrgMW := &ocmworkv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      rmnutil.ManifestWorkName("novrg", "novrg-ns", rmnutil.MWTypeVRG),
+				Namespace: clusterName,
+			},
+			Spec: nsMW.Spec,
+		}
+		Expect(k8sClient.Create(context.TODO(), vrgMW)).To(Succeed())
+
+		err = mwu.SetVRGActionInManifestWork("novrg", "novrg-ns", clusterName, string(rmn.Primary))
+		Expect(errors.Is(err, rmnutil.ErrVRGManifestNotFound)).To(BeTrue())
+	})
+})
+
+var _ = Describe("WaitForManifestWorkDeleted", func() {
+	const clusterName = "mw-wait-deleted-cluster"
+
+	var mwu *rmnutil.MWUtil
+
+	BeforeEach(func() {
+		createClusterNamespace(clusterName)
+
+		mwu = newTestMWUtil()
+	})
+
+	It("returns once the ManifestWork is deleted", func() {
+		Expect(mwu.CreateOrUpdateNamespaceManifest("wait", "wait-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		mwName := rmnutil.ManifestWorkName("wait", "wait-ns", rmnutil.MWTypeNS)
+		Expect(mwu.DeleteManifestWork(mwName, clusterName)).To(Succeed())
+		Expect(mwu.WaitForManifestWorkDeleted(mwName, clusterName, 5*time.Second)).To(Succeed())
+	})
+
+	It("times out while the ManifestWork exists", func() {
+		Expect(mwu.CreateOrUpdateNamespaceManifest("waitexists", "waitexists-ns", clusterName,
+			nil, nil, nil)).To(Succeed())
+
+		mwName := rmnutil.ManifestWorkName("waitexists", "waitexists-ns", rmnutil.MWTypeNS)
+		Expect(mwu.WaitForManifestWorkDeleted(mwName, clusterName, 2*time.Second)).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("WaitForManifestWorkApplied", func() {
+	const clusterName = "mw-wait-applied-cluster"
+
+	var mwu rmnutil.ManifestWorkManager
+
+	BeforeEach(func() {
+		createClusterNamespace(clusterName)
+
+		mwu = newTestMWUtil()
+	})
+
+	It("returns once the ManifestWork is applied", func() {
+		Expect(mwu.CreateOrUpdateNamespaceManifest("waitapplied", "waitapplied-ns", clusterName,
+			nil, nil, nil)).To(Succeed())
+
+		mwName := rmnutil.ManifestWorkName("waitapplied", "waitapplied-ns", rmnutil.MWTypeNS)
+		mw, err := mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		mw.Status.Conditions = []metav1.Condition{
+			{
+				Type:               ocmworkv1.WorkApplied,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Applied",
+				ObservedGeneration: mw.GetGeneration(),
+				LastTransitionTime: metav1.Now(),
+			},
+			{
+				Type:               ocmworkv1.WorkAvailable,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Available",
+				ObservedGeneration: mw.GetGeneration(),
+				LastTransitionTime: metav1.Now(),
+			},
+		}
+		Expect(k8sClient.Status().Update(context.TODO(), mw)).To(Succeed())
+
+		Expect(mwu.WaitForManifestWorkApplied(mwName, clusterName, 5*time.Second)).To(Succeed())
+	})
+
+	It("times out while the ManifestWork is not applied", func() {
+		Expect(mwu.CreateOrUpdateNamespaceManifest("waitnotapplied", "waitnotapplied-ns", clusterName,
+			nil, nil, nil)).To(Succeed())
+
+		mwName := rmnutil.ManifestWorkName("waitnotapplied", "waitnotapplied-ns", rmnutil.MWTypeNS)
+		Expect(mwu.WaitForManifestWorkApplied(mwName, clusterName, 2*time.Second)).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("ListNotAppliedManifestWorks", func() {
+	const clusterName = "mw-not-applied-cluster"
+
+	It("lists the ManifestWorks that are not applied, scoped by the selector", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("pending", "pending-ns", clusterName, nil, nil, nil)).To(Succeed())
+		Expect(mwu.CreateOrUpdateNamespaceManifest("applied", "applied-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		appliedMW, err := mwu.FindManifestWork(
+			rmnutil.ManifestWorkName("applied", "applied-ns", rmnutil.MWTypeNS), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		appliedMW.Status.Conditions = []metav1.Condition{{
+			Type:               ocmworkv1.WorkApplied,
+			Status:             metav1.ConditionTrue,
+			Reason:             "AppliedManifestWorkComplete",
+			LastTransitionTime: metav1.Now(),
+		}, {
+			Type:               ocmworkv1.WorkAvailable,
+			Status:             metav1.ConditionTrue,
+			Reason:             "ResourcesAvailable",
+			LastTransitionTime: metav1.Now(),
+		}}
+		Expect(k8sClient.Status().Update(context.TODO(), appliedMW)).To(Succeed())
+
+		unmanagedMW := &ocmworkv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{Name: "unmanaged-mw", Namespace: clusterName},
+		}
+		Expect(k8sClient.Create(context.TODO(), unmanagedMW)).To(Succeed())
+
+		mwNames := func(mws []ocmworkv1.ManifestWork) []string {
+			names := []string{}
+			for _, mw := range mws {
+				names = append(names, mw.GetName())
+			}
+
+			return names
+		}
+
+		mws, err := mwu.ListNotAppliedManifestWorks(clusterName, rmnutil.ManagedByRamenSelector())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mwNames(mws)).To(ConsistOf(rmnutil.ManifestWorkName("pending", "pending-ns", rmnutil.MWTypeNS)))
+
+		mws, err = mwu.ListNotAppliedManifestWorks(clusterName, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mwNames(mws)).To(ConsistOf(
+			rmnutil.ManifestWorkName("pending", "pending-ns", rmnutil.MWTypeNS), "unmanaged-mw"))
+	})
+})
+
+var _ = Describe("ManifestWorkObserved", func() {
+	const clusterName = "mw-observed-cluster"
+
+	It("reports a status reflecting an older generation as not observed", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+		mwName := rmnutil.ManifestWorkName("observed", "observed-ns", rmnutil.MWTypeNS)
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("observed", "observed-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		mw, err := mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mwu.ManifestWorkObserved(mw)).To(BeFalse())
+
+		mw.Status.Conditions = []metav1.Condition{
+			{
+				Type:               ocmworkv1.WorkApplied,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Applied",
+				ObservedGeneration: mw.GetGeneration(),
+				LastTransitionTime: metav1.Now(),
+			},
+			{
+				Type:               ocmworkv1.WorkAvailable,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Available",
+				ObservedGeneration: mw.GetGeneration(),
+				LastTransitionTime: metav1.Now(),
+			},
+		}
+		Expect(k8sClient.Status().Update(context.TODO(), mw)).To(Succeed())
+
+		mw, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mwu.ManifestWorkObserved(mw)).To(BeTrue())
+		Expect(mwu.IsManifestWorkObservedAndApplied(mw)).To(BeTrue())
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("observed", "observed-ns", clusterName, nil,
+			map[string]string{"updated": "true"}, nil)).To(Succeed())
+
+		mw, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rmnutil.IsManifestInAppliedState(mw)).To(BeTrue())
+		Expect(mwu.ManifestWorkObserved(mw)).To(BeFalse())
+		Expect(mwu.IsManifestWorkObservedAndApplied(mw)).To(BeFalse())
+	})
+})
+
+var _ = Describe("DetectDuplicateVRGManifestWorks", func() {
+	const clusterName = "mw-duplicate-vrg-cluster"
+
+	It("detects VRG ManifestWorks for a DRPC under more than one name", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "duplicate", Namespace: "duplicate-ns"},
+			Spec:       validVRGSpec(),
+		}
+		annotations := map[string]string{
+			rmnutil.DRPCNameAnnotation:      "duplicate",
+			rmnutil.DRPCNamespaceAnnotation: "duplicate-drpc-ns",
+		}
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork("duplicate", "duplicate-ns", clusterName, vrg, annotations,
+			false)).To(Succeed())
+		Expect(mwu.CreateOrUpdateNamespaceManifest("duplicate", "duplicate-ns", clusterName, annotations,
+			nil, nil)).To(Succeed())
+
+		Expect(mwu.DetectDuplicateVRGManifestWorks("duplicate", "duplicate-drpc-ns", clusterName)).To(BeNil())
+
+		mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName("duplicate", "duplicate-ns", rmnutil.MWTypeVRG),
+			clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		oldFormatMW := &ocmworkv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "duplicate-duplicate-ns-vrg-old-mw",
+				Namespace:   clusterName,
+				Annotations: annotations,
+			},
+			Spec: mw.Spec,
+		}
+		Expect(k8sClient.Create(context.TODO(), oldFormatMW)).To(Succeed())
+
+		Expect(mwu.DetectDuplicateVRGManifestWorks("duplicate", "duplicate-drpc-ns", clusterName)).To(ConsistOf(
+			mw.GetName(), oldFormatMW.GetName()))
+		Expect(mwu.DetectDuplicateVRGManifestWorks("other", "duplicate-drpc-ns", clusterName)).To(BeNil())
+	})
+})
+
+var _ = Describe("AggregateDRPCManifestWorkStatus", func() {
+	const (
+		appliedCluster  = "mw-aggregate-applied-cluster"
+		degradedCluster = "mw-aggregate-degraded-cluster"
+		missingCluster  = "mw-aggregate-missing-cluster"
+	)
+
+	It("counts applied, degraded and missing ManifestWorks of a DRPC", func() {
+		mwu := newTestMWUtil()
+		annotations := map[string]string{
+			rmnutil.DRPCNameAnnotation:      "aggregate",
+			rmnutil.DRPCNamespaceAnnotation: "aggregate-drpc-ns",
+		}
+		mwName := rmnutil.ManifestWorkName("aggregate", "aggregate-ns", rmnutil.MWTypeNS)
+
+		setConditions := func(cluster string, conditions []metav1.Condition) {
+			mw, err := mwu.FindManifestWork(mwName, cluster)
+			Expect(err).NotTo(HaveOccurred())
+
+			mw.Status.Conditions = conditions
+			Expect(k8sClient.Status().Update(context.TODO(), mw)).To(Succeed())
+		}
+
+		for _, cluster := range []string{appliedCluster, degradedCluster, missingCluster} {
+			createClusterNamespace(cluster)
+		}
+
+		for _, cluster := range []string{appliedCluster, degradedCluster} {
+			Expect(mwu.CreateOrUpdateNamespaceManifest("aggregate", "aggregate-ns", cluster, annotations,
+				nil, nil)).To(Succeed())
+		}
+
+		setConditions(appliedCluster, []metav1.Condition{
+			{
+				Type:               ocmworkv1.WorkApplied,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Applied",
+				LastTransitionTime: metav1.Now(),
+			},
+			{
+				Type:               ocmworkv1.WorkAvailable,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Available",
+				LastTransitionTime: metav1.Now(),
+			},
+		})
+		setConditions(degradedCluster, []metav1.Condition{{
+			Type:               ocmworkv1.WorkDegraded,
+			Status:             metav1.ConditionTrue,
+			Reason:             "ApplyFailed",
+			Message:            "namespace forbidden",
+			LastTransitionTime: metav1.Now(),
+		}})
+
+		summary, err := mwu.AggregateDRPCManifestWorkStatus("aggregate", "aggregate-drpc-ns",
+			[]string{appliedCluster, degradedCluster, missingCluster})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(summary.Total).To(Equal(2))
+		Expect(summary.Applied).To(Equal(1))
+		Expect(summary.Degraded).To(Equal(1))
+		Expect(summary.Missing).To(Equal(1))
+		Expect(summary.Problems).To(ConsistOf(
+			rmnutil.ManifestWorkProblem{
+				Cluster: degradedCluster, Name: mwName, Reason: "ApplyFailed: namespace forbidden",
+			},
+			rmnutil.ManifestWorkProblem{Cluster: missingCluster, Reason: "no ManifestWork found"},
+		))
+	})
+})
+
+var _ = Describe("ListDRPCManifestWorks", func() {
+	const (
+		cluster1 = "mw-list-drpc-cluster1"
+		cluster2 = "mw-list-drpc-cluster2"
+	)
+
+	It("maps the ManifestWorks of a DRPC to their cluster and type", func() {
+		mwu := newTestMWUtil()
+		annotations := map[string]string{
+			rmnutil.DRPCNameAnnotation:      "list",
+			rmnutil.DRPCNamespaceAnnotation: "list-drpc-ns",
+		}
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "list", Namespace: "list-ns"},
+			Spec:       validVRGSpec(),
+		}
+
+		for _, cluster := range []string{cluster1, cluster2} {
+			createClusterNamespace(cluster)
+		}
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork("list", "list-ns", cluster1, vrg, annotations, false)).To(Succeed())
+		Expect(mwu.CreateOrUpdateNamespaceManifest("list", "list-ns", cluster1, annotations, nil, nil)).To(Succeed())
+		Expect(mwu.CreateOrUpdateNamespaceManifest("other", "list-ns", cluster1, nil, nil, nil)).To(Succeed())
+
+		clusterMWs, err := mwu.ListDRPCManifestWorks("list", "list-drpc-ns", []string{cluster1, cluster2})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusterMWs).To(HaveLen(2))
+		Expect(clusterMWs[cluster1]).To(HaveLen(2))
+		Expect(clusterMWs[cluster1][rmnutil.MWTypeVRG].GetName()).To(
+			Equal(rmnutil.ManifestWorkName("list", "list-ns", rmnutil.MWTypeVRG)))
+		Expect(clusterMWs[cluster1][rmnutil.MWTypeNS].GetName()).To(
+			Equal(rmnutil.ManifestWorkName("list", "list-ns", rmnutil.MWTypeNS)))
+		Expect(clusterMWs[cluster2]).To(BeEmpty())
+	})
+})
+
+var _ = Describe("VerifyDrClusterRBACApplied", func() {
+	const clusterName = "mw-drcluster-rbac-cluster"
+
+	It("reports whether the DRCluster RBAC resources are applied", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		Expect(mwu.VerifyDrClusterRBACApplied(clusterName)).To(BeFalse())
+		Expect(mwu.CreateOrUpdateDrClusterManifestWork(clusterName, nil, nil)).To(Succeed())
+		Expect(mwu.VerifyDrClusterRBACApplied(clusterName)).To(BeFalse())
+
+		resourceStatus := func(kind, name string, condType ocmworkv1.ManifestConditionType) ocmworkv1.ManifestCondition {
+			return ocmworkv1.ManifestCondition{
+				ResourceMeta: ocmworkv1.ManifestResourceMeta{
+					Group: "rbac.authorization.k8s.io", Version: "v1", Kind: kind, Name: name,
+				},
+				Conditions: []metav1.Condition{{
+					Type:               string(condType),
+					Status:             metav1.ConditionTrue,
+					Reason:             string(condType),
+					Message:            "test",
+					LastTransitionTime: metav1.Now(),
+				}},
+			}
+		}
+
+		setResourceStatus := func(vrgRoleBindingCondType ocmworkv1.ManifestConditionType) {
+			const (
+				vrgRBACName   = "open-cluster-management:klusterlet-work-sa:agent:volrepgroup-edit"
+				mModeRBACName = "open-cluster-management:klusterlet-work-sa:agent:mmode-edit"
+			)
+
+			mw, err := mwu.GetDrClusterManifestWork(clusterName)
+			Expect(err).NotTo(HaveOccurred())
+
+			mw.Status.Conditions = []metav1.Condition{}
+			mw.Status.ResourceStatus.Manifests = []ocmworkv1.ManifestCondition{
+				resourceStatus("ClusterRole", vrgRBACName, ocmworkv1.ManifestApplied),
+				resourceStatus("ClusterRoleBinding", vrgRBACName, vrgRoleBindingCondType),
+				resourceStatus("ClusterRole", mModeRBACName, ocmworkv1.ManifestApplied),
+				resourceStatus("ClusterRoleBinding", mModeRBACName, ocmworkv1.ManifestApplied),
+			}
+			Expect(k8sClient.Status().Update(context.TODO(), mw)).To(Succeed())
+		}
+
+		setResourceStatus(ocmworkv1.ManifestApplied)
+		Expect(mwu.VerifyDrClusterRBACApplied(clusterName)).To(BeTrue())
+
+		setResourceStatus(ocmworkv1.ManifestDegraded)
+
+		applied, err := mwu.VerifyDrClusterRBACApplied(clusterName)
+		Expect(errors.Is(err, rmnutil.ErrDrClusterRBACDegraded)).To(BeTrue())
+		Expect(applied).To(BeFalse())
+	})
+})
+
+var _ = Describe("IsRetryableManifestWorkError", func() {
+	gr := schema.GroupResource{Group: "work.open-cluster-management.io", Resource: "manifestworks"}
+
+	It("classifies transient errors as retryable", func() {
+		Expect(rmnutil.IsRetryableManifestWorkError(k8serrors.NewConflict(gr, "mw", errors.New("conflict")))).
+			To(BeTrue())
+		Expect(rmnutil.IsRetryableManifestWorkError(k8serrors.NewServerTimeout(gr, "update", 1))).To(BeTrue())
+		Expect(rmnutil.IsRetryableManifestWorkError(k8serrors.NewServiceUnavailable("unavailable"))).To(BeTrue())
+		Expect(rmnutil.IsRetryableManifestWorkError(
+			fmt.Errorf("mw: %w", rmnutil.ErrManifestWorkMigrationPending))).To(BeTrue())
+		Expect(rmnutil.IsRetryableManifestWorkError(
+			fmt.Errorf("mw: %w", rmnutil.ErrClusterUnreachable))).To(BeTrue())
+		Expect(rmnutil.IsRetryableManifestWorkError(
+			fmt.Errorf("wait: %w", context.DeadlineExceeded))).To(BeTrue())
+	})
+
+	It("classifies permanent errors as not retryable", func() {
+		Expect(rmnutil.IsRetryableManifestWorkError(nil)).To(BeFalse())
+		Expect(rmnutil.IsRetryableManifestWorkError(errors.New("other"))).To(BeFalse())
+		Expect(rmnutil.IsRetryableManifestWorkError(k8serrors.NewForbidden(gr, "mw", errors.New("denied")))).
+			To(BeFalse())
+		Expect(rmnutil.IsRetryableManifestWorkError(k8serrors.NewBadRequest("bad"))).To(BeFalse())
+		Expect(rmnutil.IsRetryableManifestWorkError(
+			fmt.Errorf("mw: %w", rmnutil.ErrManifestWorkTooLarge))).To(BeFalse())
+		Expect(rmnutil.IsRetryableManifestWorkError(
+			fmt.Errorf("vrg: %w", rmnutil.ErrS3ProfileNotDefined))).To(BeFalse())
+	})
+})
+
+var _ = Describe("ManifestWorkRequeueAfter", func() {
+	It("suggests a requeue delay as per the ManifestWork state reported by the error", func() {
+		Expect(rmnutil.ManifestWorkRequeueAfter(nil)).To(BeZero())
+		Expect(rmnutil.ManifestWorkRequeueAfter(errors.New("other"))).To(BeZero())
+		Expect(rmnutil.ManifestWorkRequeueAfter(
+			fmt.Errorf("mw: %w", rmnutil.ErrManifestWorkRelocationPending))).To(Equal(rmnutil.ManifestWorkPendingRequeueDelay))
+		Expect(rmnutil.ManifestWorkRequeueAfter(
+			fmt.Errorf("mw: %w", rmnutil.ErrClusterUnreachable))).To(Equal(rmnutil.ClusterUnreachableRequeueDelay))
+	})
+})
+
+var _ = Describe("ManifestWorkSummary", func() {
+	It("summarizes a ManifestWork on a single line", func() {
+		mw := &ocmworkv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      rmnutil.ManifestWorkName("app", "app-ns", rmnutil.MWTypeVRG),
+				Namespace: "east",
+				Annotations: map[string]string{
+					rmnutil.DRPCNameAnnotation:      "app",
+					rmnutil.DRPCNamespaceAnnotation: "app-ns",
+				},
+			},
+			Spec: ocmworkv1.ManifestWorkSpec{
+				Workload: ocmworkv1.ManifestsTemplate{Manifests: []ocmworkv1.Manifest{{}}},
+			},
+		}
+
+		Expect(rmnutil.ManifestWorkSummary(mw)).To(Equal(
+			`east/app-app-ns-vrg-mw type="vrg" manifests=1 state=Pending drpc="app-ns/app"`))
+
+		mw.SetName(rmnutil.DrClusterManifestWorkName)
+		mw.SetAnnotations(nil)
+		Expect(rmnutil.ManifestWorkSummary(mw)).To(Equal(
+			`east/ramen-dr-cluster type="drcluster" manifests=1 state=Pending drpc=""`))
+	})
+})
+
+var _ = Describe("ClusterManifestWorkNamespace", func() {
+	const clusterName = "mw-cluster-namespace-cluster"
+
+	It("maps a cluster to the namespace of its ManifestWorks and back", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("mapped", "mapped-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName("mapped", "mapped-ns", rmnutil.MWTypeNS), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.GetNamespace()).To(Equal(rmnutil.ClusterManifestWorkNamespace(clusterName)))
+		Expect(rmnutil.ManifestWorkCluster(mw)).To(Equal(clusterName))
+		Expect(rmnutil.ClusterManifestWorkNamespace(metav1.NamespaceAll)).To(Equal(metav1.NamespaceAll))
+	})
+})
+
+var _ = Describe("PromoteVRG", func() {
+	const (
+		sourceCluster = "mw-promote-source-cluster"
+		targetCluster = "mw-promote-target-cluster"
+	)
+
+	var mwu *rmnutil.MWUtil
+
+	vrgOf := func(name string, state rmn.ReplicationState) rmn.VolumeReplicationGroup {
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: name + "-ns"},
+			Spec:       validVRGSpec(),
+		}
+		vrg.Spec.ReplicationState = state
+
+		return vrg
+	}
+
+	targetState := func(name string) rmn.ReplicationState {
+		mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName(name, name+"-ns", rmnutil.MWTypeVRG), targetCluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		vrg, err := rmnutil.ExtractVRGFromManifestWork(mw)
+		Expect(err).NotTo(HaveOccurred())
+
+		return vrg.Spec.ReplicationState
+	}
+
+	BeforeEach(func() {
+		for _, cluster := range []string{sourceCluster, targetCluster} {
+			createClusterNamespace(cluster)
+		}
+
+		mwu = newTestMWUtil()
+	})
+
+	It("promotes only once the source VRG is demoted and applied", func() {
+		const name = "promote"
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork(name, name+"-ns", sourceCluster, vrgOf(name, rmn.Primary),
+			nil, false)).To(Succeed())
+
+		err := mwu.PromoteVRG(name, name+"-ns", targetCluster, vrgOf(name, rmn.Secondary), true, sourceCluster)
+		Expect(errors.Is(err, rmnutil.ErrSourceVRGNotDemoted)).To(BeTrue())
+		Expect(rmnutil.ManifestWorkRequeueAfter(err)).To(Equal(rmnutil.ManifestWorkPendingRequeueDelay))
+
+		Expect(mwu.SetVRGActionInManifestWork(name, name+"-ns", sourceCluster, string(rmn.Secondary))).To(Succeed())
+
+		err = mwu.PromoteVRG(name, name+"-ns", targetCluster, vrgOf(name, rmn.Secondary), true, sourceCluster)
+		Expect(errors.Is(err, rmnutil.ErrSourceVRGNotDemoted)).To(BeTrue())
+
+		mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName(name, name+"-ns", rmnutil.MWTypeVRG), sourceCluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		mw.Status.Conditions = []metav1.Condition{
+			{
+				Type:               ocmworkv1.WorkApplied,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Applied",
+				ObservedGeneration: mw.GetGeneration(),
+				LastTransitionTime: metav1.Now(),
+			},
+			{
+				Type:               ocmworkv1.WorkAvailable,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Available",
+				ObservedGeneration: mw.GetGeneration(),
+				LastTransitionTime: metav1.Now(),
+			},
+		}
+		Expect(k8sClient.Status().Update(context.TODO(), mw)).To(Succeed())
+
+		Expect(mwu.PromoteVRG(name, name+"-ns", targetCluster, vrgOf(name, rmn.Secondary), true,
+			sourceCluster)).To(Succeed())
+		Expect(targetState(name)).To(Equal(rmn.Primary))
+	})
+
+	It("promotes without a source VRG ManifestWork or when not required", func() {
+		Expect(mwu.PromoteVRG("promotenosrc", "promotenosrc-ns", targetCluster, vrgOf("promotenosrc", rmn.Secondary),
+			true, sourceCluster)).To(Succeed())
+		Expect(targetState("promotenosrc")).To(Equal(rmn.Primary))
+
+		Expect(mwu.PromoteVRG("promoteany", "promoteany-ns", targetCluster, vrgOf("promoteany", rmn.Secondary),
+			false, "")).To(Succeed())
+		Expect(targetState("promoteany")).To(Equal(rmn.Primary))
+	})
+})
+
+var _ = Describe("Cluster namespace missing", func() {
+	const clusterName = "mw-unregistered-cluster"
+
+	It("returns a retryable ErrClusterNamespaceMissing instead of creating the ManifestWork", func() {
+		mwu := newTestMWUtil()
+
+		err := mwu.CreateOrUpdateNamespaceManifest("unregistered", "unregistered-ns", clusterName, nil, nil, nil)
+		Expect(errors.Is(err, rmnutil.ErrClusterNamespaceMissing)).To(BeTrue())
+		Expect(rmnutil.ManifestWorkRequeueAfter(err)).To(Equal(rmnutil.ClusterUnreachableRequeueDelay))
+		Expect(rmnutil.IsRetryableManifestWorkError(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Paused cluster", func() {
+	const clusterName = "mw-paused-cluster"
+
+	var (
+		mwu       *rmnutil.MWUtil
+		drCluster *rmn.DRCluster
+	)
+
+	setPaused := func(paused bool) {
+		Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(drCluster), drCluster)).To(Succeed())
+
+		annotations := map[string]string{}
+		if paused {
+			annotations[rmnutil.ManifestWorksPausedAnnotation] = rmnutil.ManifestWorksPausedValue
+		}
+
+		drCluster.SetAnnotations(annotations)
+		Expect(k8sClient.Update(context.TODO(), drCluster)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		createClusterNamespace(clusterName)
+
+		drCluster = &rmn.DRCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+			Spec:       rmn.DRClusterSpec{S3ProfileName: "s3-profile"},
+		}
+		Expect(client.IgnoreAlreadyExists(k8sClient.Create(context.TODO(), drCluster))).To(Succeed())
+
+		mwu = newTestMWUtil()
+	})
+
+	It("skips ManifestWork changes while the cluster is paused", func() {
+		mwName := rmnutil.ManifestWorkName("paused", "paused-ns", rmnutil.MWTypeNS)
+
+		setPaused(true)
+
+		paused, err := mwu.IsClusterPaused(clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paused).To(BeTrue())
+
+		err = mwu.CreateOrUpdateNamespaceManifest("paused", "paused-ns", clusterName, nil, nil, nil)
+		Expect(errors.Is(err, rmnutil.ErrClusterReconciliationPaused)).To(BeTrue())
+		Expect(rmnutil.ManifestWorkRequeueAfter(err)).To(Equal(rmnutil.ClusterUnreachableRequeueDelay))
+
+		_, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		setPaused(false)
+		Expect(mwu.CreateOrUpdateNamespaceManifest("paused", "paused-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		setPaused(true)
+		Expect(errors.Is(mwu.DeleteManifestWork(mwName, clusterName), rmnutil.ErrClusterReconciliationPaused)).
+			To(BeTrue())
+
+		_, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		setPaused(false)
+		Expect(mwu.DeleteManifestWork(mwName, clusterName)).To(Succeed())
+	})
+
+	It("deletes ManifestWorks while the cluster is paused when finalizing", func() {
+		mwName := rmnutil.ManifestWorkName("paused-finalizing", "paused-ns", rmnutil.MWTypeNS)
+
+		setPaused(false)
+		Expect(mwu.CreateOrUpdateNamespaceManifest("paused-finalizing", "paused-ns", clusterName, nil, nil, nil)).
+			To(Succeed())
+
+		setPaused(true)
+		DeferCleanup(setPaused, false)
+
+		mwu.Finalizing = true
+		Expect(mwu.DeleteManifestWork(mwName, clusterName)).To(Succeed())
+		Expect(mwu.WaitForManifestWorkDeleted(mwName, clusterName, 5*time.Second)).To(Succeed())
+
+		err := mwu.CreateOrUpdateNamespaceManifest("paused-finalizing", "paused-ns", clusterName, nil, nil, nil)
+		Expect(errors.Is(err, rmnutil.ErrClusterReconciliationPaused)).To(BeTrue())
+	})
+
+	It("is not paused without a DRCluster", func() {
+		paused, err := mwu.IsClusterPaused("mw-paused-no-drcluster")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(paused).To(BeFalse())
+	})
+})
+
+var _ = Describe("ManifestWorkSpecEqual", func() {
+	const clusterName = "mw-spec-equal-cluster"
+
+	specOf := func(raws ...string) ocmworkv1.ManifestWorkSpec {
+		spec := ocmworkv1.ManifestWorkSpec{}
+
+		for _, raw := range raws {
+			manifest := ocmworkv1.Manifest{}
+			manifest.Raw = []byte(raw)
+			spec.Workload.Manifests = append(spec.Workload.Manifests, manifest)
+		}
+
+		return spec
+	}
+
+	It("ignores the encoding, server populated metadata and an empty status", func() {
+		Expect(rmnutil.ManifestWorkSpecEqual(
+			specOf(`{"apiVersion":"scheduling.k8s.io/v1","kind":"PriorityClass","metadata":{"name":"p"},"value":1000000}`),
+			specOf(`{ "kind": "PriorityClass", "apiVersion": "scheduling.k8s.io/v1", "value": 1e+06,
+				"metadata": {"name": "p", "creationTimestamp": null}, "status": {} }`),
+		)).To(BeTrue())
+	})
+
+	It("detects a change in the manifests", func() {
+		Expect(rmnutil.ManifestWorkSpecEqual(
+			specOf(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"a"}}`),
+			specOf(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"b"}}`),
+		)).To(BeFalse())
+		Expect(rmnutil.ManifestWorkSpecEqual(
+			specOf(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"a"}}`),
+			specOf(),
+		)).To(BeFalse())
+	})
+
+	It("does not update a ManifestWork created by an older release on each reconcile", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+		mwName := rmnutil.ManifestWorkName("equal", "equal-ns", rmnutil.MWTypeNS)
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("equal", "equal-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		// As created by an older release, without the spec hash and with an unsanitized manifest
+		mw, err := mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		delete(mw.Annotations, rmnutil.SpecHashAnnotation)
+		mw.Spec.Workload.Manifests[0].Raw = []byte(`{"kind":"Namespace","apiVersion":"v1",` +
+			`"metadata":{"name":"equal-ns","creationTimestamp":null},"spec":{},"status":{}}`)
+		Expect(k8sClient.Update(context.TODO(), mw)).To(Succeed())
+
+		mw, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		generation := mw.GetGeneration()
+
+		for i := 0; i < 2; i++ {
+			Expect(mwu.CreateOrUpdateNamespaceManifest("equal", "equal-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+			mw, err = mwu.FindManifestWork(mwName, clusterName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mw.GetGeneration()).To(Equal(generation))
+		}
+	})
+})
+
+var _ = Describe("ManifestWorkSpecHash", func() {
+	const clusterName = "mw-spec-hash-cluster"
+
+	specOf := func(raws ...string) ocmworkv1.ManifestWorkSpec {
+		spec := ocmworkv1.ManifestWorkSpec{}
+
+		for _, raw := range raws {
+			manifest := ocmworkv1.Manifest{}
+			manifest.Raw = []byte(raw)
+			spec.Workload.Manifests = append(spec.Workload.Manifests, manifest)
+		}
+
+		return spec
+	}
+
+	It("is stable across field order and whitespace", func() {
+		Expect(rmnutil.ManifestWorkSpecHash(specOf(`{"kind":"Namespace","apiVersion":"v1"}`))).To(Equal(
+			rmnutil.ManifestWorkSpecHash(specOf(`{ "apiVersion": "v1", "kind": "Namespace" }`))))
+		Expect(rmnutil.ManifestWorkSpecHash(specOf(`{"kind":"Namespace","apiVersion":"v1"}`))).NotTo(Equal(
+			rmnutil.ManifestWorkSpecHash(specOf(`{"kind":"Secret","apiVersion":"v1"}`))))
+	})
+
+	It("is stamped on created and updated ManifestWorks", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+		mwName := rmnutil.ManifestWorkName("hash", "hash-ns", rmnutil.MWTypeNS)
+
+		for _, nsLabels := range []map[string]string{nil, {"updated": "true"}} {
+			Expect(mwu.CreateOrUpdateNamespaceManifest("hash", "hash-ns", clusterName, nil, nsLabels,
+				nil)).To(Succeed())
+
+			mw, err := mwu.FindManifestWork(mwName, clusterName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mw.GetAnnotations()).To(HaveKeyWithValue(rmnutil.SpecHashAnnotation,
+				rmnutil.ManifestWorkSpecHash(mw.Spec)))
+		}
+	})
+
+	It("does not keep a spec changed by other than Ramen", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+		mwName := rmnutil.ManifestWorkName("hash-drift", "hash-drift-ns", rmnutil.MWTypeNS)
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("hash-drift", "hash-drift-ns", clusterName, nil, nil,
+			nil)).To(Succeed())
+
+		mw, err := mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		generated := mw.Spec.DeepCopy()
+		mw.Spec.Workload.Manifests[0].Raw = []byte(
+			`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"hash-drift-ns","labels":{"edited":"true"}}}`)
+		Expect(k8sClient.Update(context.TODO(), mw)).To(Succeed())
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("hash-drift", "hash-drift-ns", clusterName, nil, nil,
+			nil)).To(Succeed())
+
+		mw, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rmnutil.ManifestWorkSpecEqual(mw.Spec, *generated)).To(BeTrue())
+	})
+})
+
+var _ = Describe("ValidateManifestWork", func() {
+	mwOf := func(raws ...string) *ocmworkv1.ManifestWork {
+		mw := &ocmworkv1.ManifestWork{ObjectMeta: metav1.ObjectMeta{Name: "validate", Namespace: "validate-cluster"}}
+
+		for _, raw := range raws {
+			manifest := ocmworkv1.Manifest{}
+			manifest.Raw = []byte(raw)
+			mw.Spec.Workload.Manifests = append(mw.Spec.Workload.Manifests, manifest)
+		}
+
+		return mw
+	}
+
+	It("accepts namespaced and cluster scoped objects", func() {
+		Expect(rmnutil.ValidateManifestWork(mwOf(
+			`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"ns"}}`,
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm","namespace":"ns"}}`,
+			`{"apiVersion":"storage.k8s.io/v1","kind":"VolumeAttachment","metadata":{"name":"va"}}`,
+		), k8sClient.RESTMapper())).To(Succeed())
+	})
+
+	It("does not require a namespace for kinds the hub does not serve", func() {
+		Expect(rmnutil.ValidateManifestWork(mwOf(
+			`{"apiVersion":"example.com/v1","kind":"Widget","metadata":{"name":"widget"}}`,
+		), k8sClient.RESTMapper())).To(Succeed())
+		Expect(rmnutil.ValidateManifestWork(mwOf(
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"}}`,
+		), nil)).To(Succeed())
+	})
+
+	It("reports every invalid manifest", func() {
+		err := rmnutil.ValidateManifestWork(mwOf(
+			`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":"ns"}}`,
+			`not json`,
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"namespace":"ns"}}`,
+			`{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm"}}`,
+		), k8sClient.RESTMapper())
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).NotTo(ContainSubstring("manifest 0"))
+		Expect(err.Error()).To(ContainSubstring("manifest 1"))
+		Expect(err.Error()).To(ContainSubstring("manifest 2"))
+		Expect(err.Error()).To(ContainSubstring("manifest 3"))
+	})
+})
+
+var _ = Describe("ManifestWorkNotAppliedDuration", func() {
+	mwu := &rmnutil.MWUtil{Log: ctrl.Log.WithName("MWUtilTest")}
+
+	newMW := func(created time.Time, conditions ...metav1.Condition) *ocmworkv1.ManifestWork {
+		return &ocmworkv1.ManifestWork{
+			ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+			Status:     ocmworkv1.ManifestWorkStatus{Conditions: conditions},
+		}
+	}
+
+	appliedCondition := func(status metav1.ConditionStatus, transitioned time.Time) metav1.Condition {
+		return metav1.Condition{
+			Type:               ocmworkv1.WorkApplied,
+			Status:             status,
+			LastTransitionTime: metav1.NewTime(transitioned),
+		}
+	}
+
+	It("measures from creation until the applied condition is reported", func() {
+		mw := newMW(time.Now().Add(-time.Hour))
+
+		Expect(mwu.ManifestWorkAge(mw)).To(BeNumerically(">=", time.Hour))
+		Expect(mwu.ManifestWorkNotAppliedDuration(mw)).To(BeNumerically(">=", time.Hour))
+		Expect(mwu.ManifestWorkApplyOverdue(mw, 30*time.Minute)).To(BeTrue())
+		Expect(mwu.ManifestWorkApplyOverdue(mw, 2*time.Hour)).To(BeFalse())
+	})
+
+	It("measures from the last transition of a false applied condition", func() {
+		mw := newMW(time.Now().Add(-time.Hour), appliedCondition(metav1.ConditionFalse, time.Now().Add(-time.Minute)))
+
+		Expect(mwu.ManifestWorkNotAppliedDuration(mw)).To(BeNumerically("<", 30*time.Minute))
+		Expect(mwu.ManifestWorkApplyOverdue(mw, 30*time.Minute)).To(BeFalse())
+	})
+
+	It("is zero once applied", func() {
+		mw := newMW(time.Now().Add(-time.Hour), appliedCondition(metav1.ConditionTrue, time.Now().Add(-time.Hour)))
+
+		Expect(mwu.ManifestWorkNotAppliedDuration(mw)).To(BeZero())
+		Expect(mwu.ManifestWorkApplyOverdue(mw, 0)).To(BeFalse())
+	})
+})
+
+var _ = Describe("DRCluster ManifestWork extra manifests", func() {
+	const clusterName = "mw-drcluster-extra-cluster"
+
+	var mwu *rmnutil.MWUtil
+
+	BeforeEach(func() {
+		createClusterNamespace(clusterName)
+
+		mwu = newTestMWUtil()
+	})
+
+	It("appends the extra manifests to the DRCluster ManifestWork", func() {
+		priorityClass := runtime.RawExtension{
+			Raw: []byte(`{"apiVersion":"scheduling.k8s.io/v1","kind":"PriorityClass",` +
+				`"metadata":{"name":"dr-critical"},"value":1000000}`),
+		}
+
+		Expect(mwu.CreateOrUpdateDrClusterManifestWork(clusterName, nil, nil, priorityClass)).To(Succeed())
+
+		mw, err := mwu.GetDrClusterManifestWork(clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		manifests := mw.Spec.Workload.Manifests
+		Expect(string(manifests[len(manifests)-1].Raw)).To(ContainSubstring(`"dr-critical"`))
+	})
+
+	It("appends extra manifests of any cluster scoped kind", func() {
+		volumeAttachment := runtime.RawExtension{
+			Raw: []byte(`{"apiVersion":"storage.k8s.io/v1","kind":"VolumeAttachment","metadata":{"name":"dr-va"},` +
+				`"spec":{"attacher":"csi.example.com","nodeName":"node","source":{"persistentVolumeName":"pv"}}}`),
+		}
+		widget := runtime.RawExtension{
+			Raw: []byte(`{"apiVersion":"example.com/v1","kind":"Widget","metadata":{"name":"dr-widget"}}`),
+		}
+
+		Expect(mwu.CreateOrUpdateDrClusterManifestWork(clusterName, nil, nil, volumeAttachment, widget)).To(Succeed())
+
+		mw, err := mwu.GetDrClusterManifestWork(clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		manifests := mw.Spec.Workload.Manifests
+		Expect(string(manifests[len(manifests)-2].Raw)).To(ContainSubstring(`"dr-va"`))
+		Expect(string(manifests[len(manifests)-1].Raw)).To(ContainSubstring(`"dr-widget"`))
+	})
+
+	It("fails for a malformed extra manifest", func() {
+		networkPolicy := runtime.RawExtension{
+			Raw: []byte(`{"apiVersion":"networking.k8s.io/v1","kind":"NetworkPolicy","metadata":{"name":"np"}}`),
+		}
+
+		Expect(mwu.CreateOrUpdateDrClusterManifestWork(clusterName, nil, nil, networkPolicy)).NotTo(Succeed())
+	})
+
+	// The work agent, which prunes the resources dropped from a ManifestWork on the managed cluster, does not run in
+	// the test environment, hence only the removal from the ManifestWork is verified
+	It("drops an extra manifest that is no longer passed from the DRCluster ManifestWork", func() {
+		priorityClass := runtime.RawExtension{
+			Raw: []byte(`{"apiVersion":"scheduling.k8s.io/v1","kind":"PriorityClass",` +
+				`"metadata":{"name":"dr-pruned"},"value":1000000}`),
+		}
+
+		Expect(mwu.CreateOrUpdateDrClusterManifestWork(clusterName, nil, nil, priorityClass)).To(Succeed())
+
+		mw, err := mwu.GetDrClusterManifestWork(clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		count := len(mw.Spec.Workload.Manifests)
+
+		Expect(mwu.CreateOrUpdateDrClusterManifestWork(clusterName, nil, nil)).To(Succeed())
+
+		mw, err = mwu.GetDrClusterManifestWork(clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.Spec.Workload.Manifests).To(HaveLen(count - 1))
+
+		for _, manifest := range mw.Spec.Workload.Manifests {
+			Expect(string(manifest.Raw)).NotTo(ContainSubstring(`"dr-pruned"`))
+		}
+	})
+})
+
+var _ = Describe("ClustersWithManifestWorksForDRPC", func() {
+	const (
+		eastCluster  = "mw-located-east-cluster"
+		westCluster  = "mw-located-west-cluster"
+		otherCluster = "mw-located-other-cluster"
+	)
+
+	It("returns the clusters with ManifestWorks for the DRPC", func() {
+		mwu := newTestMWUtil()
+		drpcAnnotations := func(name string) map[string]string {
+			return map[string]string{
+				rmnutil.DRPCNameAnnotation:      name,
+				rmnutil.DRPCNamespaceAnnotation: "located-drpc-ns",
+			}
+		}
+
+		for _, cluster := range []string{eastCluster, westCluster, otherCluster} {
+			createClusterNamespace(cluster)
+		}
+
+		for _, cluster := range []string{westCluster, eastCluster} {
+			Expect(mwu.CreateOrUpdateNamespaceManifest("located", "located-ns", cluster, drpcAnnotations("located"),
+				nil, nil)).To(Succeed())
+		}
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("other", "other-ns", otherCluster, drpcAnnotations("other"),
+			nil, nil)).To(Succeed())
+
+		Expect(mwu.ClustersWithManifestWorksForDRPC("located", "located-drpc-ns")).To(Equal(
+			[]string{eastCluster, westCluster}))
+		Expect(mwu.ClustersWithManifestWorksForDRPC("missing", "located-drpc-ns")).To(BeEmpty())
+	})
+})
+
+var _ = Describe("CreateOrUpdateNamespacesManifest", func() {
+	const clusterName = "mw-namespaces-cluster"
+
+	var mwu *rmnutil.MWUtil
+
+	BeforeEach(func() {
+		createClusterNamespace(clusterName)
+
+		mwu = newTestMWUtil()
+	})
+
+	It("bundles the namespaces in a single ManifestWork named for the first", func() {
+		Expect(mwu.CreateOrUpdateNamespacesManifest("multi", []string{"multi-app", "multi-db"}, clusterName,
+			nil, nil, nil)).To(Succeed())
+
+		mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName("multi", "multi-app", rmnutil.MWTypeNS), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.Spec.Workload.Manifests).To(HaveLen(2))
+		Expect(string(mw.Spec.Workload.Manifests[1].Raw)).To(ContainSubstring(`"name":"multi-db"`))
+	})
+
+	It("fails for duplicate or invalid namespace names", func() {
+		Expect(mwu.CreateOrUpdateNamespacesManifest("dup", []string{"dup-app", "dup-app"}, clusterName,
+			nil, nil, nil)).To(MatchError(ContainSubstring("duplicate")))
+		Expect(mwu.CreateOrUpdateNamespacesManifest("invalid", []string{"invalid-app", "Invalid_DB"}, clusterName,
+			nil, nil, nil)).NotTo(Succeed())
+		Expect(mwu.CreateOrUpdateNamespacesManifest("none", nil, clusterName, nil, nil, nil)).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("ManifestWork protection finalizer", func() {
+	const clusterName = "mw-finalizer-cluster"
+
+	var mwu *rmnutil.MWUtil
+
+	BeforeEach(func() {
+		createClusterNamespace(clusterName)
+
+		mwu = newTestMWUtil(func(m *rmnutil.MWUtil) { m.ProtectionFinalizer = true })
+	})
+
+	It("is added when enabled and removed by DeleteManifestWork", func() {
+		Expect(mwu.CreateOrUpdateNamespaceManifest("fin", "fin-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		mwName := rmnutil.ManifestWorkName("fin", "fin-ns", rmnutil.MWTypeNS)
+		mw, err := mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.GetFinalizers()).To(ContainElement(rmnutil.ManifestWorkProtectionFinalizer))
+
+		Expect(mwu.DeleteManifestWork(mwName, clusterName)).To(Succeed())
+		Expect(mwu.WaitForManifestWorkDeleted(mwName, clusterName, 5*time.Second)).To(Succeed())
+	})
+
+	It("retains a ManifestWork deleted otherwise until RemoveManifestWorkFinalizer", func() {
+		Expect(mwu.CreateOrUpdateNamespaceManifest("finext", "finext-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		mwName := rmnutil.ManifestWorkName("finext", "finext-ns", rmnutil.MWTypeNS)
+		mw, err := mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(k8sClient.Delete(context.TODO(), mw)).To(Succeed())
+
+		mw, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.GetDeletionTimestamp().IsZero()).To(BeFalse())
+
+		Expect(mwu.RemoveManifestWorkFinalizer(mwName, clusterName)).To(Succeed())
+		Expect(mwu.WaitForManifestWorkDeleted(mwName, clusterName, 5*time.Second)).To(Succeed())
+		Expect(mwu.RemoveManifestWorkFinalizer(mwName, clusterName)).To(Succeed())
+	})
+
+	It("is not added unless enabled", func() {
+		mwu.ProtectionFinalizer = false
+		Expect(mwu.CreateOrUpdateNamespaceManifest("nofin", "nofin-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName("nofin", "nofin-ns", rmnutil.MWTypeNS), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.GetFinalizers()).NotTo(ContainElement(rmnutil.ManifestWorkProtectionFinalizer))
+	})
+})
+
+var _ = Describe("ExportDRPCManifestWorks", func() {
+	const clusterName = "mw-export-drpc-cluster"
+
+	It("exports the VRG ManifestWork objects annotated with their cluster, skipping clusters without one", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "export", Namespace: "export-ns"},
+			Spec:       validVRGSpec(),
+		}
+		Expect(mwu.CreateOrUpdateVRGManifestWork("export", "export-ns", clusterName, vrg, nil, false)).To(Succeed())
+
+		exported, err := mwu.ExportDRPCManifestWorks("export", "export-ns",
+			[]string{clusterName, "mw-export-drpc-missing-cluster"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Count(string(exported), "---\n")).To(Equal(1))
+		Expect(string(exported)).To(ContainSubstring("kind: VolumeReplicationGroup"))
+		Expect(string(exported)).To(ContainSubstring(rmnutil.ExportedFromClusterAnnotation + ": " + clusterName))
+	})
+})
+
+var _ = Describe("VRG ManifestWork labels", func() {
+	const clusterName = "mw-vrg-labels-cluster"
+
+	It("adds the configured labels to VRG ManifestWorks without overriding the Ramen labels", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "labeled", Namespace: "labeled-ns"},
+			Spec:       validVRGSpec(),
+		}
+		Expect(mwu.CreateOrUpdateVRGManifestWork("labeled", "labeled-ns", clusterName, vrg, nil, false)).To(Succeed())
+
+		mwName := rmnutil.ManifestWorkName("labeled", "labeled-ns", rmnutil.MWTypeVRG)
+
+		mw, err := mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.GetLabels()).NotTo(HaveKey("tenant"))
+
+		mwu.VRGManifestWorkLabels = map[string]string{
+			"tenant":               "blue",
+			rmnutil.ManagedByLabel: "other",
+		}
+		Expect(mwu.CreateOrUpdateVRGManifestWork("labeled", "labeled-ns", clusterName, vrg, nil, false)).To(Succeed())
+
+		mw, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.GetLabels()).To(HaveKeyWithValue("tenant", "blue"))
+		Expect(mw.GetLabels()).To(HaveKeyWithValue(rmnutil.ManagedByLabel, rmnutil.ManagedByLabelValue))
+
+		mwu.VRGManifestWorkLabels["tenant"] = "green"
+		Expect(mwu.CreateOrUpdateVRGManifestWork("labeled", "labeled-ns", clusterName, vrg, nil, false)).To(Succeed())
+
+		mw, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.GetLabels()).To(HaveKeyWithValue("tenant", "green"))
+		Expect(mw.GetLabels()).To(HaveKeyWithValue(rmnutil.ManagedByLabel, rmnutil.ManagedByLabelValue))
+	})
+})
+
+var _ = Describe("BackfillDRPCAnnotations", func() {
+	const clusterName = "mw-backfill-cluster"
+
+	It("adds the missing DRPC annotations so that the ManifestWorks are listed for the DRPC", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "backfill", Namespace: "backfill-ns"},
+			Spec:       validVRGSpec(),
+		}
+		Expect(mwu.CreateOrUpdateVRGManifestWork("backfill", "backfill-ns", clusterName, vrg, nil, false)).
+			To(Succeed())
+
+		clusters, err := mwu.ClustersWithManifestWorksForDRPC("backfill", "backfill-ns")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters).NotTo(ContainElement(clusterName))
+
+		Expect(mwu.BackfillDRPCAnnotations(clusterName, "backfill", "backfill-ns")).To(Succeed())
+
+		clusters, err = mwu.ClustersWithManifestWorksForDRPC("backfill", "backfill-ns")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters).To(ContainElement(clusterName))
+
+		Expect(mwu.BackfillDRPCAnnotations(clusterName, "backfill", "backfill-ns")).To(Succeed())
+	})
+})
+
+var _ = Describe("FindOrphanedManifestWorks", func() {
+	const clusterName = "mw-orphaned-cluster"
+
+	It("returns the ManifestWorks of DRPCs that no longer exist", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		for _, name := range []string{"live", "deleted"} {
+			Expect(mwu.CreateOrUpdateNamespaceManifest(name, name+"-ns", clusterName, map[string]string{
+				rmnutil.DRPCNameAnnotation:      name,
+				rmnutil.DRPCNamespaceAnnotation: "orphaned-drpc-ns",
+			}, nil, nil)).To(Succeed())
+		}
+		Expect(mwu.CreateOrUpdateDrClusterManifestWork(clusterName, nil, nil)).To(Succeed())
+
+		orphans, err := mwu.FindOrphanedManifestWorks(map[string]bool{"orphaned-drpc-ns/live": true}, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(orphans).To(HaveLen(1))
+		Expect(orphans[0].GetName()).To(Equal(rmnutil.ManifestWorkName("deleted", "deleted-ns", rmnutil.MWTypeNS)))
+		Expect(orphans[0].GetAnnotations()).To(HaveKeyWithValue(rmnutil.DRPCNameAnnotation, "deleted"))
+	})
+})
+
+var _ = Describe("UpdateDRPCAnnotations", func() {
+	const clusterName = "mw-rename-cluster"
+
+	It("moves the ManifestWork to the new DRPC without updating its spec", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "rename", Namespace: "rename-ns"},
+			Spec:       validVRGSpec(),
+		}
+		Expect(mwu.CreateOrUpdateVRGManifestWork("rename", "rename-ns", clusterName, vrg, map[string]string{
+			rmnutil.DRPCNameAnnotation:      "rename",
+			rmnutil.DRPCNamespaceAnnotation: "rename-ns",
+		}, false)).To(Succeed())
+
+		mwName := rmnutil.ManifestWorkName("rename", "rename-ns", rmnutil.MWTypeVRG)
+		mw, err := mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		generation := mw.GetGeneration()
+
+		Expect(mwu.UpdateDRPCAnnotations(mwName, clusterName, "renamed", "renamed-ns")).To(Succeed())
+
+		mw, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.GetGeneration()).To(Equal(generation))
+		Expect(mw.GetAnnotations()).To(HaveKeyWithValue(rmnutil.DRPCNameAnnotation, "renamed"))
+		Expect(mw.GetAnnotations()).To(HaveKeyWithValue(rmnutil.DRPCNamespaceAnnotation, "renamed-ns"))
+
+		clusters, err := mwu.ClustersWithManifestWorksForDRPC("renamed", "renamed-ns")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(clusters).To(ContainElement(clusterName))
+
+		Expect(mwu.UpdateDRPCAnnotations("missing-mw", clusterName, "renamed", "renamed-ns")).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("VRG ManifestWork source generation", func() {
+	const clusterName = "mw-source-generation-cluster"
+
+	skips := func() float64 {
+		val, err := rmnutil.GetMetricValueWithLabels(rmnutil.ManifestWorkReconcileTotal, dto.MetricType_COUNTER,
+			map[string]string{
+				rmnutil.MWMetricLabelAction: rmnutil.MWActionSkip,
+				rmnutil.MWMetricLabelType:   rmnutil.MWTypeVRG,
+			})
+		if err != nil {
+			return 0
+		}
+
+		return val
+	}
+
+	It("skips regenerating the VRG ManifestWork until the source generation changes", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil(func(m *rmnutil.MWUtil) { m.SourceGeneration = "1" })
+
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "generation", Namespace: "generation-ns"},
+			Spec:       validVRGSpec(),
+		}
+		mwName := rmnutil.ManifestWorkName("generation", "generation-ns", rmnutil.MWTypeVRG)
+		profiles := func() []string {
+			mw, err := mwu.FindManifestWork(mwName, clusterName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mw.GetAnnotations()).To(HaveKeyWithValue(rmnutil.SourceGenerationAnnotation,
+				mwu.SourceGeneration+"/"+string(rmn.Primary)))
+
+			mwVRG, err := rmnutil.ExtractVRGFromManifestWork(mw)
+			Expect(err).NotTo(HaveOccurred())
+
+			return mwVRG.Spec.S3Profiles
+		}
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork("generation", "generation-ns", clusterName, vrg, nil, false)).
+			To(Succeed())
+		Expect(profiles()).To(Equal([]string{"s3-profile"}))
+
+		before := skips()
+		vrg.Spec.S3Profiles = []string{"s3-profile-other"}
+		Expect(mwu.CreateOrUpdateVRGManifestWork("generation", "generation-ns", clusterName, vrg, nil, false)).
+			To(Succeed())
+		Expect(skips()).To(Equal(before + 1))
+		Expect(profiles()).To(Equal([]string{"s3-profile"}))
+
+		mwu.SourceGeneration = "2"
+		Expect(mwu.CreateOrUpdateVRGManifestWork("generation", "generation-ns", clusterName, vrg, nil, false)).
+			To(Succeed())
+		Expect(skips()).To(Equal(before + 1))
+		Expect(profiles()).To(Equal([]string{"s3-profile-other"}))
+	})
+
+	It("regenerates the VRG ManifestWork after its VRG replication state is set", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil(func(m *rmnutil.MWUtil) { m.SourceGeneration = "1" })
+
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "generation-action", Namespace: "generation-ns"},
+			Spec:       validVRGSpec(),
+		}
+		mwName := rmnutil.ManifestWorkName("generation-action", "generation-ns", rmnutil.MWTypeVRG)
+		replicationState := func() rmn.ReplicationState {
+			mw, err := mwu.FindManifestWork(mwName, clusterName)
+			Expect(err).NotTo(HaveOccurred())
+
+			mwVRG, err := rmnutil.ExtractVRGFromManifestWork(mw)
+			Expect(err).NotTo(HaveOccurred())
+
+			return mwVRG.Spec.ReplicationState
+		}
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork("generation-action", "generation-ns", clusterName, vrg, nil, false)).
+			To(Succeed())
+		Expect(mwu.SetVRGActionInManifestWork("generation-action", "generation-ns", clusterName,
+			string(rmn.Secondary))).To(Succeed())
+		Expect(replicationState()).To(Equal(rmn.Secondary))
+
+		mw, err := mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.GetAnnotations()).NotTo(HaveKey(rmnutil.SourceGenerationAnnotation))
+
+		before := skips()
+		Expect(mwu.CreateOrUpdateVRGManifestWork("generation-action", "generation-ns", clusterName, vrg, nil, false)).
+			To(Succeed())
+		Expect(skips()).To(Equal(before))
+		Expect(replicationState()).To(Equal(rmn.Primary))
+	})
+})
+
+var _ = Describe("EnsureVRGManifestWork", func() {
+	const clusterName = "mw-ensure-vrg-cluster"
+
+	It("returns the ManifestWork state as of the create or update", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "ensure", Namespace: "ensure-ns"},
+			Spec:       validVRGSpec(),
+		}
+
+		state, err := mwu.EnsureVRGManifestWork("ensure", "ensure-ns", clusterName, vrg, nil, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(Equal(rmnutil.ManifestWorkStatePending))
+
+		mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName("ensure", "ensure-ns", rmnutil.MWTypeVRG), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		mw.Status.Conditions = []metav1.Condition{
+			{
+				Type:               ocmworkv1.WorkApplied,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Applied",
+				ObservedGeneration: mw.GetGeneration(),
+				LastTransitionTime: metav1.Now(),
+			},
+			{
+				Type:               ocmworkv1.WorkAvailable,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Available",
+				ObservedGeneration: mw.GetGeneration(),
+				LastTransitionTime: metav1.Now(),
+			},
+		}
+		Expect(k8sClient.Status().Update(context.TODO(), mw)).To(Succeed())
+
+		state, err = mwu.EnsureVRGManifestWork("ensure", "ensure-ns", clusterName, vrg, nil, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(Equal(rmnutil.ManifestWorkStateApplied))
+
+		vrg.Spec.ReplicationState = rmn.Secondary
+
+		state, err = mwu.EnsureVRGManifestWork("ensure", "ensure-ns", clusterName, vrg, nil, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(state).To(Equal(rmnutil.ManifestWorkStatePending))
+	})
+
+	It("reads the existing ManifestWork once to carry its annotations forward and update it", func() {
+		createClusterNamespace(clusterName)
+
+		counter := &manifestWorkGetCounter{Client: k8sClient}
+		mwu := newTestMWUtil(func(m *rmnutil.MWUtil) { m.Client = counter })
+
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "ensure-once", Namespace: "ensure-ns"},
+			Spec:       validVRGSpec(),
+		}
+
+		_, err := mwu.EnsureVRGManifestWork("ensure-once", "ensure-ns", clusterName, vrg, nil, true)
+		Expect(err).NotTo(HaveOccurred())
+
+		vrg.Spec.ReplicationState = rmn.Secondary
+		counter.gets = 0
+
+		_, err = mwu.EnsureVRGManifestWork("ensure-once", "ensure-ns", clusterName, vrg, nil, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(counter.gets).To(Equal(1))
+
+		mw, err := mwu.FindManifestWork(
+			rmnutil.ManifestWorkName("ensure-once", "ensure-ns", rmnutil.MWTypeVRG), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		updatedVRG, err := rmnutil.ExtractVRGFromManifestWork(mw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updatedVRG.Spec.ReplicationState).To(Equal(rmn.Secondary))
+		Expect(updatedVRG.GetAnnotations()).To(HaveKey(rmnutil.ForceResyncAnnotation))
+	})
+})
+
+// manifestWorkGetCounter counts the ManifestWork Gets
+type manifestWorkGetCounter struct {
+	client.Client
+	gets int
+}
+
+func (c *manifestWorkGetCounter) Get(ctx context.Context, key client.ObjectKey, obj client.Object,
+	opts ...client.GetOption,
+) error {
+	if _, ok := obj.(*ocmworkv1.ManifestWork); ok {
+		c.gets++
+	}
+
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+var _ = Describe("ManifestWork managed-by label", func() {
+	const clusterName = "mw-managed-by-cluster"
+
+	It("is added to an existing ManifestWork without it, retaining its other labels", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		Expect(mwu.CreateOrUpdateDrClusterManifestWork(clusterName, nil, nil)).To(Succeed())
+
+		mw, err := mwu.FindManifestWork(rmnutil.DrClusterManifestWorkName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rmnutil.IsManifestWorkLabeledManagedByRamen(mw)).To(BeTrue())
+
+		mw.SetLabels(map[string]string{"app": "legacy"})
+		Expect(k8sClient.Update(context.TODO(), mw)).To(Succeed())
+
+		Expect(mwu.CreateOrUpdateDrClusterManifestWork(clusterName, nil, nil)).To(Succeed())
+
+		mw, err = mwu.FindManifestWork(rmnutil.DrClusterManifestWorkName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.GetLabels()).To(HaveKeyWithValue(rmnutil.ManagedByLabel, rmnutil.ManagedByLabelValue))
+		Expect(mw.GetLabels()).To(HaveKeyWithValue("app", "legacy"))
+	})
+})
+
+var _ = Describe("ExportObjectsAsYAML", func() {
+	It("exports the DRCluster ManifestWork objects as a deterministic YAML stream", func() {
+		mwu := newTestMWUtil()
+
+		objects, err := rmnutil.DrClusterObjects(k8sClient.RESTMapper(), nil, runtime.RawExtension{
+			Raw: []byte(`{"kind":"PriorityClass","apiVersion":"scheduling.k8s.io/v1",` +
+				`"metadata":{"name":"dr-critical"},"value":1000000}`),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		exported, err := mwu.ExportObjectsAsYAML(objects...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(strings.Count(string(exported), "---\n")).To(Equal(len(objects)))
+		Expect(string(exported)).To(ContainSubstring("apiVersion: scheduling.k8s.io/v1\nkind: PriorityClass\n"))
+		Expect(string(exported)).To(ContainSubstring("value: 1000000\n"))
+		Expect(string(exported)).NotTo(ContainSubstring("creationTimestamp"))
+
+		reexported, err := mwu.ExportObjectsAsYAML(objects...)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reexported).To(Equal(exported))
+	})
+})
+
+var _ = Describe("ManifestWorkApplyErrors", func() {
+	mwu := &rmnutil.MWUtil{Log: ctrl.Log.WithName("MWUtilTest")}
+
+	manifestCondition := func(status metav1.ConditionStatus, message string) ocmworkv1.ManifestCondition {
+		return ocmworkv1.ManifestCondition{
+			Conditions: []metav1.Condition{
+				{Type: string(ocmworkv1.ManifestApplied), Status: status, Message: message},
+			},
+		}
+	}
+
+	It("collects the distinct messages of resources that failed to apply", func() {
+		mw := &ocmworkv1.ManifestWork{}
+		mw.Status.ResourceStatus.Manifests = []ocmworkv1.ManifestCondition{
+			manifestCondition(metav1.ConditionFalse, "forbidden: cannot create resource"),
+			manifestCondition(metav1.ConditionTrue, "applied"),
+			manifestCondition(metav1.ConditionFalse, "forbidden: cannot create resource"),
+			manifestCondition(metav1.ConditionFalse, "admission webhook denied the request"),
+		}
+
+		Expect(mwu.ManifestWorkApplyErrors(mw)).To(Equal([]string{
+			"forbidden: cannot create resource",
+			"admission webhook denied the request",
+		}))
+	})
+
+	It("returns no messages when the status is not populated", func() {
+		Expect(mwu.ManifestWorkApplyErrors(&ocmworkv1.ManifestWork{})).To(BeEmpty())
+	})
+})
+
+var _ = Describe("DrainCluster", func() {
+	const clusterName = "mw-drain-cluster"
+
+	It("demotes and then removes the VRG ManifestWorks on the cluster", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "drain", Namespace: "drain-ns"},
+			Spec:       validVRGSpec(),
+		}
+		mwName := rmnutil.ManifestWorkName("drain", "drain-ns", rmnutil.MWTypeVRG)
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork("drain", "drain-ns", clusterName, vrg, nil, false)).To(Succeed())
+		Expect(mwu.CreateOrUpdateNamespaceManifest("drain", "drain-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		progress, err := mwu.DrainCluster(clusterName, true)
+		Expect(errors.Is(err, rmnutil.ErrClusterDrainPending)).To(BeTrue())
+		Expect(progress.Total).To(Equal(1))
+		Expect(progress.Pending).To(ConsistOf(mwName))
+
+		mw, err := mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		mwVRG, err := rmnutil.ExtractVRGFromManifestWork(mw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mwVRG.Spec.ReplicationState).To(Equal(rmn.Secondary))
+
+		mw.Status.Conditions = []metav1.Condition{
+			{
+				Type:               ocmworkv1.WorkApplied,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Applied",
+				ObservedGeneration: mw.GetGeneration(),
+				LastTransitionTime: metav1.Now(),
+			},
+			{
+				Type:               ocmworkv1.WorkAvailable,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Available",
+				ObservedGeneration: mw.GetGeneration(),
+				LastTransitionTime: metav1.Now(),
+			},
+		}
+		Expect(k8sClient.Status().Update(context.TODO(), mw)).To(Succeed())
+
+		progress, err = mwu.DrainCluster(clusterName, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(progress.Demoted).To(Equal(1))
+		Expect(progress.Removed).To(Equal(1))
+
+		_, err = mwu.FindManifestWork(mwName, clusterName)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		_, err = mwu.FindManifestWork(rmnutil.ManifestWorkName("drain", "drain-ns", rmnutil.MWTypeNS), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		// Idempotent once drained
+		progress, err = mwu.DrainCluster(clusterName, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(progress.Total).To(BeZero())
+	})
+})
+
+var _ = Describe("DeleteManifestWorksBySelector", func() {
+	const clusterName = "mw-delete-selector-cluster"
+
+	It("deletes the matching Ramen managed ManifestWorks and reports the others", func() {
+		createClusterNamespace(clusterName)
+
+		mwu := newTestMWUtil()
+
+		newMW := func(name, policy string, managed bool) *ocmworkv1.ManifestWork {
+			mwLabels := map[string]string{"policy": policy}
+			if managed {
+				mwLabels[rmnutil.ManagedByLabel] = rmnutil.ManagedByLabelValue
+			}
+
+			return &ocmworkv1.ManifestWork{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: clusterName, Labels: mwLabels},
+			}
+		}
+
+		managedMWs := []*ocmworkv1.ManifestWork{newMW("policy-mw-1", "p1", true), newMW("policy-mw-2", "p1", true)}
+		otherMW := newMW("other-policy-mw", "p2", true)
+		unmanagedMW := newMW("unmanaged-policy-mw", "p1", false)
+
+		for _, mw := range append(managedMWs, otherMW, unmanagedMW) {
+			Expect(k8sClient.Create(context.TODO(), mw)).To(Succeed())
+		}
+
+		err := mwu.DeleteManifestWorksBySelector(clusterName, labels.SelectorFromSet(labels.Set{"policy": "p1"}))
+		Expect(errors.Is(err, rmnutil.ErrManifestWorkNotManaged)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("unmanaged-policy-mw"))
+
+		for _, mw := range managedMWs {
+			Expect(k8serrors.IsNotFound(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(mw), mw))).To(BeTrue())
+		}
+
+		Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(otherMW), otherMW)).To(Succeed())
+		Expect(k8sClient.Get(context.TODO(), client.ObjectKeyFromObject(unmanagedMW), unmanagedMW)).To(Succeed())
+	})
+
+	It("refuses an empty selector", func() {
+		mwu := newTestMWUtil()
+
+		Expect(mwu.DeleteManifestWorksBySelector(clusterName, labels.Everything())).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("RelocateVRGManifestWork", func() {
+	const (
+		fromCluster = "mw-relocate-from-cluster"
+		toCluster   = "mw-relocate-to-cluster"
+	)
+
+	It("deletes the VRG ManifestWork on the old cluster once applied on the new cluster", func() {
+		for _, cluster := range []string{fromCluster, toCluster} {
+			createClusterNamespace(cluster)
+		}
+
+		mwu := newTestMWUtil()
+
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "relocate", Namespace: "relocate-ns"},
+			Spec:       validVRGSpec(),
+		}
+		mwName := rmnutil.ManifestWorkName("relocate", "relocate-ns", rmnutil.MWTypeVRG)
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork("relocate", "relocate-ns", fromCluster, vrg,
+			map[string]string{rmnutil.DRPCNameAnnotation: "relocate"}, false)).To(Succeed())
+
+		err := mwu.RelocateVRGManifestWork("relocate", "relocate-ns", fromCluster, toCluster, vrg)
+		Expect(errors.Is(err, rmnutil.ErrManifestWorkRelocationPending)).To(BeTrue())
+
+		_, err = mwu.FindManifestWork(mwName, fromCluster)
+		Expect(err).NotTo(HaveOccurred())
+
+		toMW, err := mwu.FindManifestWork(mwName, toCluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(toMW.GetAnnotations()).To(HaveKeyWithValue(rmnutil.DRPCNameAnnotation, "relocate"))
+
+		toMW.Status.Conditions = []metav1.Condition{
+			{
+				Type:               ocmworkv1.WorkApplied,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Applied",
+				LastTransitionTime: metav1.Now(),
+			},
+			{
+				Type:               ocmworkv1.WorkAvailable,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Available",
+				LastTransitionTime: metav1.Now(),
+			},
+		}
+		Expect(k8sClient.Status().Update(context.TODO(), toMW)).To(Succeed())
+
+		Expect(mwu.RelocateVRGManifestWork("relocate", "relocate-ns", fromCluster, toCluster, vrg)).To(Succeed())
+
+		_, err = mwu.FindManifestWork(mwName, fromCluster)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		// Idempotent once relocated
+		Expect(mwu.RelocateVRGManifestWork("relocate", "relocate-ns", fromCluster, toCluster, vrg)).To(Succeed())
+	})
+
+	It("fails to relocate to the same cluster", func() {
+		mwu := newTestMWUtil()
+
+		Expect(mwu.RelocateVRGManifestWork("relocate", "relocate-ns", fromCluster, fromCluster,
+			rmn.VolumeReplicationGroup{})).NotTo(Succeed())
+	})
+})
+
+var _ = Describe("ManifestWork snapshots", func() {
+	const clusterName = "mw-snapshot-cluster"
+
+	var mwu *rmnutil.MWUtil
+
+	mwName := rmnutil.ManifestWorkName("snapshot", "snapshot-ns", rmnutil.MWTypeNS)
+
+	BeforeEach(func() {
+		createClusterNamespace(clusterName)
+
+		mwu = newTestMWUtil()
+	})
+
+	It("restores the ManifestWork spec captured in a snapshot", func() {
+		Expect(mwu.CreateOrUpdateNamespaceManifest("snapshot", "snapshot-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		capturedMW, err := mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		snapshot, err := mwu.CaptureManifestWorkSnapshot(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("snapshot", "snapshot-ns", clusterName, nil,
+			map[string]string{"edited": "true"}, nil)).To(Succeed())
+
+		editedMW, err := mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(editedMW.Spec).NotTo(Equal(capturedMW.Spec))
+
+		Expect(mwu.RestoreManifestWorkSnapshot(clusterName, snapshot)).To(Succeed())
+
+		restoredMW, err := mwu.FindManifestWork(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(restoredMW.Spec).To(Equal(capturedMW.Spec))
+	})
+
+	It("refuses to restore a snapshot to a different cluster", func() {
+		Expect(mwu.CreateOrUpdateNamespaceManifest("snapshot", "snapshot-ns", clusterName, nil, nil, nil)).To(Succeed())
+
+		snapshot, err := mwu.CaptureManifestWorkSnapshot(mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(mwu.RestoreManifestWorkSnapshot("other-cluster", snapshot)).NotTo(Succeed())
+	})
+})
+
+// getErrorClient fails all Gets with err
+type getErrorClient struct {
+	client.Client
+	err error
+}
+
+func (c getErrorClient) Get(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error {
+	return c.err
+}
+
+var _ = Describe("FindManifestWork cluster unreachable", func() {
+	findWithGetError := func(err error) error {
+		mwu := newTestMWUtil(func(m *rmnutil.MWUtil) { m.Client = getErrorClient{Client: k8sClient, err: err} })
+
+		_, err = mwu.FindManifestWork("unreachable-mw", "unreachable-cluster")
+
+		return err
+	}
+
+	mwResource := schema.GroupResource{Group: ocmworkv1.GroupName, Resource: "manifestworks"}
+
+	It("returns ErrClusterUnreachable for timeout errors", func() {
+		for _, getErr := range []error{
+			k8serrors.NewTimeoutError("timed out", 1),
+			k8serrors.NewServerTimeout(mwResource, "get", 1),
+		} {
+			Expect(errors.Is(findWithGetError(getErr), rmnutil.ErrClusterUnreachable)).To(BeTrue())
+		}
+	})
+
+	It("returns a forbidden error as is, and not retryable", func() {
+		err := findWithGetError(k8serrors.NewForbidden(mwResource, "unreachable-mw", fmt.Errorf("denied")))
+		Expect(k8serrors.IsForbidden(err)).To(BeTrue())
+		Expect(errors.Is(err, rmnutil.ErrClusterUnreachable)).To(BeFalse())
+		Expect(rmnutil.IsRetryableManifestWorkError(err)).To(BeFalse())
+		Expect(rmnutil.ManifestWorkRequeueAfter(err)).To(BeZero())
+
+		err = findWithGetError(k8serrors.NewServerTimeout(mwResource, "get", 1))
+		Expect(rmnutil.IsRetryableManifestWorkError(err)).To(BeTrue())
+	})
+
+	It("returns not found and other errors as is", func() {
+		err := findWithGetError(k8serrors.NewNotFound(mwResource, "unreachable-mw"))
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+		Expect(errors.Is(err, rmnutil.ErrClusterUnreachable)).To(BeFalse())
+
+		err = findWithGetError(k8serrors.NewInternalError(fmt.Errorf("internal")))
+		Expect(errors.Is(err, rmnutil.ErrClusterUnreachable)).To(BeFalse())
+	})
+})
+
+var _ = Describe("UpdateDrClusterManifestWorkObjects", func() {
+	const clusterName = "mw-drcluster-partial-cluster"
+
+	var mwu *rmnutil.MWUtil
+
+	configMap := func(data string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "partial-config", Namespace: "ramen-system"},
+			Data:       map[string]string{"config": data},
+		}
+	}
+
+	BeforeEach(func() {
+		createClusterNamespace(clusterName)
+
+		mwu = newTestMWUtil()
+	})
+
+	It("fails when the ManifestWork is absent", func() {
+		err := mwu.UpdateDrClusterManifestWorkObjects(clusterName, []interface{}{configMap("v1")})
+		Expect(errors.Is(err, rmnutil.ErrManifestWorkPartialUpdate)).To(BeTrue())
+	})
+
+	It("replaces only the manifest of the passed in object", func() {
+		Expect(mwu.CreateOrUpdateDrClusterManifestWork(clusterName, []interface{}{configMap("v1")},
+			nil)).To(Succeed())
+
+		mw, err := mwu.GetDrClusterManifestWork(clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		manifests := mw.Spec.Workload.Manifests
+		last := len(manifests) - 1
+
+		Expect(mwu.UpdateDrClusterManifestWorkObjects(clusterName, []interface{}{configMap("v2")})).To(Succeed())
+
+		mw, err = mwu.GetDrClusterManifestWork(clusterName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mw.Spec.Workload.Manifests).To(HaveLen(len(manifests)))
+		Expect(mw.Spec.Workload.Manifests[:last]).To(Equal(manifests[:last]))
+
+		updatedConfigMap := &corev1.ConfigMap{}
+		Expect(json.Unmarshal(mw.Spec.Workload.Manifests[last].Raw, updatedConfigMap)).To(Succeed())
+		Expect(updatedConfigMap.Data).To(HaveKeyWithValue("config", "v2"))
+	})
+
+	It("fails for an object not in the ManifestWork", func() {
+		other := configMap("v1")
+		other.Name = "other-config"
+
+		err := mwu.UpdateDrClusterManifestWorkObjects(clusterName, []interface{}{other})
+		Expect(errors.Is(err, rmnutil.ErrManifestWorkPartialUpdate)).To(BeTrue())
+	})
+})
+
+var _ = Describe("WatchManifestWork", func() {
+	const clusterName = "mw-watch-cluster"
+
+	It("streams ManifestWork changes until the context is cancelled", func() {
+		createClusterNamespace(clusterName)
+
+		watchClient, err := client.NewWithWatch(cfg, client.Options{Scheme: k8sClient.Scheme()})
+		Expect(err).NotTo(HaveOccurred())
+
+		mwu := newTestMWUtil(func(m *rmnutil.MWUtil) { m.WatchClient = watchClient })
+
+		mwName := rmnutil.ManifestWorkName("watch", "watch-ns", rmnutil.MWTypeNS)
+
+		ctx, cancel := context.WithCancel(context.TODO())
+		defer cancel()
+
+		mws, err := mwu.WatchManifestWork(ctx, mwName, clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(mwu.CreateOrUpdateNamespaceManifest("watch", "watch-ns", clusterName, nil, nil, nil)).To(Succeed())
+		Eventually(mws).Should(Receive(WithTransform(func(mw *ocmworkv1.ManifestWork) string {
+			return mw.GetName()
+		}, Equal(mwName))))
+
+		Expect(mwu.DeleteManifestWork(mwName, clusterName)).To(Succeed())
+		Eventually(mws).Should(Receive(BeNil()))
+
+		cancel()
+		Eventually(mws).Should(BeClosed())
+	})
+
+	It("requires a watch client", func() {
+		mwu := newTestMWUtil()
+
+		_, err := mwu.WatchManifestWork(context.TODO(), "watch-mw", clusterName)
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("CreateOrUpdateVRGManifestWork for the local cluster", func() {
+	const (
+		localClusterName = "local-cluster"
+		vrgNamespace     = "local-vrg-ns"
+	)
+
+	It("creates and updates the VRG directly instead of a ManifestWork", func() {
+		createNamespace(vrgNamespace)
+
+		mwu := newTestMWUtil(func(m *rmnutil.MWUtil) { m.LocalClusterName = localClusterName })
+
+		vrg := rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "local", Namespace: vrgNamespace},
+			Spec:       validVRGSpec(),
+		}
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork("local", vrgNamespace, localClusterName, vrg, nil, true)).To(Succeed())
+
+		localVRG := &rmn.VolumeReplicationGroup{}
+		Expect(k8sClient.Get(context.TODO(), client.ObjectKey{Name: "local", Namespace: vrgNamespace},
+			localVRG)).To(Succeed())
+		Expect(localVRG.Spec.ReplicationState).To(Equal(rmn.Primary))
+
+		resyncValue := localVRG.GetAnnotations()[rmnutil.ForceResyncAnnotation]
+		Expect(resyncValue).NotTo(BeEmpty())
+
+		_, err := mwu.FindManifestWork(rmnutil.ManifestWorkName("local", vrgNamespace, rmnutil.MWTypeVRG),
+			localClusterName)
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+		vrg.Spec.ReplicationState = rmn.Secondary
+		Expect(mwu.CreateOrUpdateVRGManifestWork("local", vrgNamespace, localClusterName, vrg, nil,
+			false)).To(Succeed())
+
+		Expect(k8sClient.Get(context.TODO(), client.ObjectKey{Name: "local", Namespace: vrgNamespace},
+			localVRG)).To(Succeed())
+		Expect(localVRG.Spec.ReplicationState).To(Equal(rmn.Secondary))
+		Expect(localVRG.GetAnnotations()).To(HaveKeyWithValue(rmnutil.ForceResyncAnnotation, resyncValue))
+	})
+})
+
+var _ = Describe("Preflight", func() {
+	It("succeeds when the ManifestWork API is served", func() {
+		mwu := newTestMWUtil()
+		Expect(mwu.Preflight()).To(Succeed())
+	})
+
+	It("fails clearly when the ManifestWork API is not served", func() {
+		noOCMClient, err := client.New(cfg, client.Options{
+			Scheme: scheme.Scheme,
+			Mapper: meta.NewDefaultRESTMapper(nil),
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		mwu := newTestMWUtil(func(m *rmnutil.MWUtil) { m.Client = noOCMClient })
+		Expect(errors.Is(mwu.Preflight(), rmnutil.ErrOCMNotInstalled)).To(BeTrue())
+	})
+})
+
+var _ = Describe("NewMWUtil", func() {
+	log := ctrl.Log.WithName("MWUtilTest")
+
+	It("returns an MWUtil with the required fields set", func() {
+		mwu, err := rmnutil.NewMWUtil(k8sClient, context.TODO(), log, "name", "namespace")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mwu.Client).To(Equal(k8sClient))
+		Expect(mwu.APIReader).To(Equal(k8sClient))
+		Expect(mwu.InstName).To(Equal("name"))
+		Expect(mwu.TargetNamespace).To(Equal("namespace"))
+		Expect(mwu.BuildManifestWorkName(rmnutil.MWTypeVRG)).To(Equal("name-namespace-vrg-mw"))
+	})
+
+	It("fails when a required field is unset", func() {
+		var nilCtx context.Context
+
+		_, err := rmnutil.NewMWUtil(nil, context.TODO(), log, "name", "namespace")
+		Expect(err).To(HaveOccurred())
+
+		_, err = rmnutil.NewMWUtil(k8sClient, nilCtx, log, "name", "namespace")
+		Expect(err).To(HaveOccurred())
+
+		_, err = rmnutil.NewMWUtil(k8sClient, context.TODO(), log, "", "namespace")
+		Expect(err).To(HaveOccurred())
+
+		_, err = rmnutil.NewMWUtil(k8sClient, context.TODO(), log, "name", "")
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("FindPrimaryClusterForDRPC", func() {
+	const (
+		cluster1 = "mw-primary-cluster1"
+		cluster2 = "mw-primary-cluster2"
+	)
+
+	var mwu *rmnutil.MWUtil
+
+	vrg := func(name string, state rmn.ReplicationState) rmn.VolumeReplicationGroup {
+		spec := validVRGSpec()
+		spec.ReplicationState = state
+
+		return rmn.VolumeReplicationGroup{
+			TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "primary-ns"},
+			Spec:       spec,
+		}
+	}
+
+	BeforeEach(func() {
+		for _, cluster := range []string{cluster1, cluster2} {
+			createClusterNamespace(cluster)
+		}
+
+		mwu = newTestMWUtil()
+	})
+
+	It("returns the cluster with the Primary VRG", func() {
+		Expect(mwu.CreateOrUpdateVRGManifestWork("single", "primary-ns", cluster1,
+			vrg("single", rmn.Secondary), nil, false)).To(Succeed())
+		Expect(mwu.CreateOrUpdateVRGManifestWork("single", "primary-ns", cluster2,
+			vrg("single", rmn.Primary), nil, false)).To(Succeed())
+
+		Expect(mwu.FindPrimaryClusterForDRPC("single", "primary-ns", []string{cluster1, cluster2})).To(Equal(cluster2))
+	})
+
+	It("fails when no cluster has a Primary VRG", func() {
+		Expect(mwu.CreateOrUpdateVRGManifestWork("none", "primary-ns", cluster1,
+			vrg("none", rmn.Secondary), nil, false)).To(Succeed())
+
+		_, err := mwu.FindPrimaryClusterForDRPC("none", "primary-ns", []string{cluster1, cluster2})
+		Expect(errors.Is(err, rmnutil.ErrNoPrimaryCluster)).To(BeTrue())
+	})
+
+	It("fails when multiple clusters have a Primary VRG", func() {
+		for _, cluster := range []string{cluster1, cluster2} {
+			Expect(mwu.CreateOrUpdateVRGManifestWork("split", "primary-ns", cluster,
+				vrg("split", rmn.Primary), nil, false)).To(Succeed())
+		}
+
+		_, err := mwu.FindPrimaryClusterForDRPC("split", "primary-ns", []string{cluster1, cluster2})
+		Expect(errors.Is(err, rmnutil.ErrMultiplePrimaryClusters)).To(BeTrue())
+	})
+})
+
+// notFoundMWClient is a ManifestWorkClient without any ManifestWorks
+type notFoundMWClient struct{}
+
+func (notFoundMWClient) Get(_ context.Context, key client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+	return k8serrors.NewNotFound(schema.GroupResource{Group: ocmworkv1.GroupName, Resource: "manifestworks"}, key.Name)
+}
+
+func (notFoundMWClient) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	return nil
+}
+
+func (notFoundMWClient) Create(context.Context, client.Object, ...client.CreateOption) error {
+	return fmt.Errorf("unexpected create")
+}
+
+func (notFoundMWClient) Update(context.Context, client.Object, ...client.UpdateOption) error {
+	return fmt.Errorf("unexpected update")
+}
+
+func (notFoundMWClient) Patch(context.Context, client.Object, client.Patch, ...client.PatchOption) error {
+	return fmt.Errorf("unexpected patch")
+}
+
+func (notFoundMWClient) Delete(context.Context, client.Object, ...client.DeleteOption) error {
+	return fmt.Errorf("unexpected delete")
+}
+
+var _ = Describe("ManifestWorkClient", func() {
+	It("allows MWUtil to use a client other than a controller-runtime client", func() {
+		mwu := &rmnutil.MWUtil{
+			Client: notFoundMWClient{},
+			Ctx:    context.TODO(),
+			Log:    ctrl.Log.WithName("MWUtilTest"),
+		}
+
+		Expect(mwu.DeleteManifestWork("absent-mw", "fake-cluster")).To(Succeed())
+
+		_, err := mwu.FindManifestWork("absent-mw", "fake-cluster")
+		Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+	})
+})
+
+// vrgMWClient is a ManifestWorkClient holding a single ManifestWork in memory, in a cluster namespace that exists
+type vrgMWClient struct {
+	notFoundMWClient
+	mw *ocmworkv1.ManifestWork
+}
+
+func (c *vrgMWClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object,
+	opts ...client.GetOption,
+) error {
+	switch o := obj.(type) {
+	case *corev1.Namespace:
+		o.Name = key.Name
+
+		return nil
+	case *ocmworkv1.ManifestWork:
+		if c.mw != nil {
+			c.mw.DeepCopyInto(o)
+
+			return nil
+		}
+	}
+
+	return c.notFoundMWClient.Get(ctx, key, obj, opts...)
+}
+
+func (c *vrgMWClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	c.mw, _ = obj.(*ocmworkv1.ManifestWork)
+	c.mw = c.mw.DeepCopy()
+
+	return nil
+}
+
+func (c *vrgMWClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	c.mw, _ = obj.(*ocmworkv1.ManifestWork)
+	c.mw = c.mw.DeepCopy()
+
+	return nil
+}
+
+// BenchmarkEnsureVRGManifestWork measures a DRPC reconcile ensuring an unchanged VRG ManifestWork, with the VRG
+// regenerated and compared, and with it skipped for a SourceGeneration the ManifestWork is stamped with
+func BenchmarkEnsureVRGManifestWork(b *testing.B) {
+	vrg := rmn.VolumeReplicationGroup{
+		TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "bench", Namespace: "bench-ns"},
+		Spec:       validVRGSpec(),
+	}
+
+	for name, sourceGeneration := range map[string]string{"regenerate": "", "skip": "1"} {
+		sourceGeneration := sourceGeneration
+
+		b.Run(name, func(b *testing.B) {
+			mwu := &rmnutil.MWUtil{
+				Client:           &vrgMWClient{},
+				Ctx:              context.TODO(),
+				Log:              ctrl.Log.WithName("MWUtilBenchmark"),
+				SourceGeneration: sourceGeneration,
+			}
+
+			if _, err := mwu.EnsureVRGManifestWork("bench", "bench-ns", "bench-cluster", vrg, nil, false); err != nil {
+				b.Fatal(err)
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := mwu.EnsureVRGManifestWork("bench", "bench-ns", "bench-cluster", vrg, nil,
+					false); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"sync"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+)
+
+const (
+	defaultSpreadApplyConcurrency = 4
+	defaultSpreadApplyJitter      = 2 * time.Second
+
+	// DefaultMaxConcurrentApplies is the number of clusters the MWUtil ApplySpreader applies to concurrently unless
+	// MWUtil.MaxConcurrentApplies is set
+	DefaultMaxConcurrentApplies = 10
+)
+
+// ApplySpreader spreads applying changes to a set of clusters over time, instead of applying them to all clusters
+// in lockstep and spiking the API server load on the hub. It is used for applies looping over clusters within a
+// single reconcile, see MWUtil.ApplySpreader. A RamenConfig change is instead fanned out to the DRCluster
+// ManifestWorks through the DRCluster reconcile queue, one cluster per reconcile, and hence is not applied in
+// lockstep.
+type ApplySpreader struct {
+	// Concurrency is the maximum number of clusters to which changes are applied concurrently
+	Concurrency int
+
+	// Jitter is the maximum random delay before applying changes to each cluster
+	Jitter time.Duration
+}
+
+// DefaultApplySpreader is the ApplySpreader used by SpreadApply
+var DefaultApplySpreader = ApplySpreader{
+	Concurrency: defaultSpreadApplyConcurrency,
+	Jitter:      defaultSpreadApplyJitter,
+}
+
+// ApplySpreader returns the ApplySpreader for applying the ManifestWorks of a DR operation to many clusters, such as
+// demoting the VRGs on all the clusters of a DRPolicy, up to MaxConcurrentApplies at a time. It has no jitter, as
+// unlike configuration changes these applies are on the DR operation's critical path.
+func (mwu *MWUtil) ApplySpreader() ApplySpreader {
+	concurrency := DefaultMaxConcurrentApplies
+	if mwu.MaxConcurrentApplies > 0 {
+		concurrency = mwu.MaxConcurrentApplies
+	}
+
+	// Initialized ahead of the concurrent applies that read it
+	mwu.operationID()
+
+	return ApplySpreader{Concurrency: concurrency}
+}
+
+// SpreadApply calls fn for each cluster using DefaultApplySpreader
+func SpreadApply(clusters []string, fn func(string) error) error {
+	return DefaultApplySpreader.SpreadApply(clusters, fn)
+}
+
+// SpreadApply calls fn for each cluster, at most Concurrency at a time, each after a random delay of up to Jitter.
+// It waits for all calls to complete and returns an aggregate of the errors returned, if any.
+func (s ApplySpreader) SpreadApply(clusters []string, fn func(string) error) error {
+	concurrency := s.Concurrency
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mtx  sync.Mutex
+		errs []error
+	)
+
+	semaphore := make(chan struct{}, concurrency)
+
+	for _, cluster := range clusters {
+		wg.Add(1)
+
+		go func(cluster string) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			if s.Jitter > 0 {
+				time.Sleep(time.Duration(utilrand.Int63nRange(0, int64(s.Jitter))))
+			}
+
+			if err := fn(cluster); err != nil {
+				mtx.Lock()
+				errs = append(errs, err)
+				mtx.Unlock()
+			}
+		}(cluster)
+	}
+
+	wg.Wait()
+
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/ramendr/ramen/controllers/util"
+)
+
+var _ = Describe("SpreadApply", func() {
+	clusters := []string{"cluster1", "cluster2", "cluster3", "cluster4", "cluster5"}
+
+	It("applies to every cluster without exceeding the concurrency limit", func() {
+		spreader := util.ApplySpreader{Concurrency: 2, Jitter: 10 * time.Millisecond}
+
+		var (
+			mtx            sync.Mutex
+			inFlight       int
+			maxInFlight    int
+			appliedCluster = map[string]bool{}
+		)
+
+		err := spreader.SpreadApply(clusters, func(cluster string) error {
+			mtx.Lock()
+			inFlight++
+			if inFlight > maxInFlight {
+				maxInFlight = inFlight
+			}
+			appliedCluster[cluster] = true
+			mtx.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+
+			mtx.Lock()
+			inFlight--
+			mtx.Unlock()
+
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(appliedCluster).To(HaveLen(len(clusters)))
+		Expect(maxInFlight).To(BeNumerically("<=", 2))
+	})
+
+	It("returns the errors from failed applies", func() {
+		spreader := util.ApplySpreader{Concurrency: 3}
+
+		err := spreader.SpreadApply(clusters, func(cluster string) error {
+			if cluster == "cluster2" || cluster == "cluster4" {
+				return fmt.Errorf("apply to %s failed", cluster)
+			}
+
+			return nil
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("apply to cluster2 failed"))
+		Expect(err.Error()).To(ContainSubstring("apply to cluster4 failed"))
+	})
+
+	It("limits the MWUtil ApplySpreader to MaxConcurrentApplies without jitter", func() {
+		Expect((&util.MWUtil{}).ApplySpreader()).To(Equal(
+			util.ApplySpreader{Concurrency: util.DefaultMaxConcurrentApplies}))
+		Expect((&util.MWUtil{MaxConcurrentApplies: 2}).ApplySpreader()).To(Equal(util.ApplySpreader{Concurrency: 2}))
+	})
+})
@@ -16,9 +16,14 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/format"
+	clrapiv1beta1 "github.com/open-cluster-management-io/api/cluster/v1beta1"
+	ocmworkv1 "github.com/open-cluster-management/api/work/v1"
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
 	"github.com/ramendr/ramen/controllers/util"
 	plrv1 "github.com/stolostron/multicloud-operators-placementrule/pkg/apis/apps/v1"
 	"go.uber.org/zap/zapcore"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	cpcv1 "open-cluster-management.io/config-policy-controller/api/v1"
@@ -89,6 +94,15 @@ var _ = BeforeSuite(func() {
 	err = gppv1.AddToScheme(scheme.Scheme)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = ocmworkv1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = rmn.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = clrapiv1beta1.AddToScheme(scheme.Scheme)
+	Expect(err).NotTo(HaveOccurred())
+
 	By("Creating a k8s client")
 	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
 	Expect(err).NotTo(HaveOccurred())
@@ -107,3 +121,30 @@ var _ = AfterSuite(func() {
 	err := testEnv.Stop()
 	Expect(err).NotTo(HaveOccurred())
 })
+
+// newTestMWUtil returns an MWUtil using the test environment client, modified by opts
+func newTestMWUtil(opts ...func(*util.MWUtil)) *util.MWUtil {
+	mwu := &util.MWUtil{
+		Client:    k8sClient,
+		APIReader: k8sClient,
+		Ctx:       context.TODO(),
+		Log:       ctrl.Log.WithName("MWUtilTest"),
+	}
+
+	for _, opt := range opts {
+		opt(mwu)
+	}
+
+	return mwu
+}
+
+// createNamespace creates the namespace name, unless it exists
+func createNamespace(name string) {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	Expect(client.IgnoreAlreadyExists(k8sClient.Create(context.TODO(), ns))).To(Succeed())
+}
+
+// createClusterNamespace creates the hub namespace of the ManifestWorks for cluster, unless it exists
+func createClusterNamespace(cluster string) {
+	createNamespace(util.ClusterManifestWorkNamespace(cluster))
+}
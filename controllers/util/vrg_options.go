@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"fmt"
+	"strings"
+
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+)
+
+// VRGOption sets fields of a VRG shipped in a ManifestWork, returning an error if the requested value is invalid
+type VRGOption func(*rmn.VolumeReplicationGroup) error
+
+// WithVRGAsync sets the VRG async (regional DR) spec, a nil spec leaves the VRG without async replication
+func WithVRGAsync(async *rmn.VRGAsyncSpec) VRGOption {
+	return func(vrg *rmn.VolumeReplicationGroup) error {
+		if async == nil {
+			vrg.Spec.Async = nil
+
+			return nil
+		}
+
+		asyncSpec := *async
+		vrg.Spec.Async = &asyncSpec
+
+		return nil
+	}
+}
+
+// WithVRGSync sets the VRG sync (metro DR) spec, a nil spec leaves the VRG without sync replication
+func WithVRGSync(sync *rmn.VRGSyncSpec) VRGOption {
+	return func(vrg *rmn.VolumeReplicationGroup) error {
+		if sync == nil {
+			vrg.Spec.Sync = nil
+
+			return nil
+		}
+
+		syncSpec := *sync
+		vrg.Spec.Sync = &syncSpec
+
+		return nil
+	}
+}
+
+// WithVRGVolSync sets the VRG VolSync spec
+func WithVRGVolSync(volSync rmn.VolSyncSpec) VRGOption {
+	return func(vrg *rmn.VolumeReplicationGroup) error {
+		volSync.DeepCopyInto(&vrg.Spec.VolSync)
+
+		return nil
+	}
+}
+
+// WithVRGVolSyncDisabled sets whether VolSync is disabled for the VRG
+func WithVRGVolSyncDisabled(disabled bool) VRGOption {
+	return func(vrg *rmn.VolumeReplicationGroup) error {
+		vrg.Spec.VolSync.Disabled = disabled
+
+		return nil
+	}
+}
+
+// WithVRGS3Profiles sets the VRG s3Profiles, failing if any of the profile names is not defined in s3StoreProfiles,
+// the S3 store profiles of the hub RamenConfig, as the VRG would otherwise fail to protect PV cluster data
+func WithVRGS3Profiles(s3ProfileNames []string, s3StoreProfiles []rmn.S3StoreProfile) VRGOption {
+	return func(vrg *rmn.VolumeReplicationGroup) error {
+		if len(s3ProfileNames) == 0 {
+			return fmt.Errorf("VRG %s/%s no s3Profiles specified", vrg.GetNamespace(), vrg.GetName())
+		}
+
+		defined := make(map[string]struct{}, len(s3StoreProfiles))
+		for i := range s3StoreProfiles {
+			defined[s3StoreProfiles[i].S3ProfileName] = struct{}{}
+		}
+
+		for _, s3ProfileName := range s3ProfileNames {
+			if _, ok := defined[s3ProfileName]; !ok {
+				return fmt.Errorf("VRG %s/%s s3Profile %s: %w", vrg.GetNamespace(), vrg.GetName(), s3ProfileName,
+					ErrS3ProfileNotDefined)
+			}
+		}
+
+		vrg.Spec.S3Profiles = append([]string(nil), s3ProfileNames...)
+
+		return nil
+	}
+}
+
+// ramenAnnotationDomain is the domain of annotation keys reserved for use by Ramen, including its subdomains
+const ramenAnnotationDomain = "ramendr.openshift.io"
+
+// reservedVRGAnnotations are annotation keys, outside of the Ramen domain, that are never propagated to a VRG
+var reservedVRGAnnotations = map[string]struct{}{
+	"kubectl.kubernetes.io/last-applied-configuration": {},
+}
+
+// IsReservedVRGAnnotation returns true for annotation keys that may not be propagated to a VRG, which are keys
+// prefixed with ramendr.openshift.io or one of its subdomains, and kubectl.kubernetes.io/last-applied-configuration
+func IsReservedVRGAnnotation(key string) bool {
+	if _, ok := reservedVRGAnnotations[key]; ok {
+		return true
+	}
+
+	prefix, _, found := strings.Cut(key, "/")
+	if !found {
+		return false
+	}
+
+	return prefix == ramenAnnotationDomain || strings.HasSuffix(prefix, "."+ramenAnnotationDomain)
+}
+
+// WithVRGPropagatedAnnotations merges annotations, such as those set by users on a DRPC, onto the VRG annotations,
+// skipping reserved keys as per IsReservedVRGAnnotation so that these do not conflict with the ones used by Ramen
+func WithVRGPropagatedAnnotations(annotations map[string]string) VRGOption {
+	return func(vrg *rmn.VolumeReplicationGroup) error {
+		if len(annotations) == 0 {
+			return nil
+		}
+
+		vrgAnnotations := make(map[string]string, len(vrg.GetAnnotations())+len(annotations))
+
+		for key, value := range annotations {
+			if !IsReservedVRGAnnotation(key) {
+				vrgAnnotations[key] = value
+			}
+		}
+
+		for key, value := range vrg.GetAnnotations() {
+			vrgAnnotations[key] = value
+		}
+
+		vrg.SetAnnotations(vrgAnnotations)
+
+		return nil
+	}
+}
+
+// applyVRGOptions applies opts to vrg and validates the resulting replication mode specific fields
+func applyVRGOptions(vrg *rmn.VolumeReplicationGroup, opts ...VRGOption) error {
+	for _, opt := range opts {
+		if err := opt(vrg); err != nil {
+			return err
+		}
+	}
+
+	return validateVRGReplicationMode(vrg)
+}
+
+// ValidateVRGSpec checks the VRG spec for misconfigurations that would prevent the VRG from ever being reconciled on
+// the managed cluster, so that these are reported on the hub instead of when the VRG is applied
+func ValidateVRGSpec(vrg *rmn.VolumeReplicationGroup) error {
+	if vrg.Spec.Async == nil && vrg.Spec.Sync == nil {
+		return fmt.Errorf("VRG %s/%s has neither sync nor async mode enabled", vrg.GetNamespace(), vrg.GetName())
+	}
+
+	if len(vrg.Spec.PVCSelector.MatchLabels) == 0 && len(vrg.Spec.PVCSelector.MatchExpressions) == 0 &&
+		(vrg.Spec.KubeObjectProtection == nil || vrg.Spec.KubeObjectProtection.RecipeRef == nil) {
+		return fmt.Errorf("VRG %s/%s has an empty pvcSelector and no recipe to select volumes",
+			vrg.GetNamespace(), vrg.GetName())
+	}
+
+	if len(vrg.Spec.S3Profiles) == 0 {
+		return fmt.Errorf("VRG %s/%s has no s3Profiles", vrg.GetNamespace(), vrg.GetName())
+	}
+
+	return validateVRGReplicationMode(vrg)
+}
+
+func validateVRGReplicationMode(vrg *rmn.VolumeReplicationGroup) error {
+	if vrg.Spec.Async != nil && vrg.Spec.Async.SchedulingInterval == "" {
+		return fmt.Errorf("VRG %s/%s async spec missing schedulingInterval", vrg.GetNamespace(), vrg.GetName())
+	}
+
+	if vrg.Spec.VolSync.Disabled && len(vrg.Spec.VolSync.RDSpec) != 0 {
+		return fmt.Errorf("VRG %s/%s has VolSync rdSpec while VolSync is disabled", vrg.GetNamespace(), vrg.GetName())
+	}
+
+	if len(vrg.Spec.VolSync.RDSpec) != 0 && vrg.Spec.Async == nil {
+		return fmt.Errorf("VRG %s/%s has VolSync rdSpec without an async spec", vrg.GetNamespace(), vrg.GetName())
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+// SPDX-FileCopyrightText: The RamenDR authors
+// SPDX-License-Identifier: Apache-2.0
+
+package util_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	rmn "github.com/ramendr/ramen/api/v1alpha1"
+	rmnutil "github.com/ramendr/ramen/controllers/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+var _ = Describe("CreateOrUpdateVRGManifestWork replication options", func() {
+	const clusterName = "mw-vrg-options-cluster"
+
+	var mwu *rmnutil.MWUtil
+
+	vrg := rmn.VolumeReplicationGroup{
+		TypeMeta:   metav1.TypeMeta{Kind: "VolumeReplicationGroup", APIVersion: "ramendr.openshift.io/v1alpha1"},
+		ObjectMeta: metav1.ObjectMeta{Name: "options", Namespace: "options-ns"},
+		Spec:       validVRGSpec(),
+	}
+
+	BeforeEach(func() {
+		createClusterNamespace(clusterName)
+
+		mwu = newTestMWUtil()
+	})
+
+	It("sets the async and VolSync fields of the VRG", func() {
+		rdSpec := []rmn.VolSyncReplicationDestinationSpec{{ProtectedPVC: rmn.ProtectedPVC{Name: "pvc"}}}
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork("options", "options-ns", clusterName, vrg, nil, false,
+			rmnutil.WithVRGAsync(&rmn.VRGAsyncSpec{SchedulingInterval: "5m"}),
+			rmnutil.WithVRGSync(nil),
+			rmnutil.WithVRGVolSync(rmn.VolSyncSpec{RDSpec: rdSpec}),
+		)).To(Succeed())
+
+		mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName("options", "options-ns", rmnutil.MWTypeVRG), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		mwVRG, err := rmnutil.ExtractVRGFromManifestWork(mw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mwVRG.Spec.Async).NotTo(BeNil())
+		Expect(mwVRG.Spec.Async.SchedulingInterval).To(Equal("5m"))
+		Expect(mwVRG.Spec.Sync).To(BeNil())
+		Expect(mwVRG.Spec.VolSync.RDSpec).To(Equal(rdSpec))
+	})
+
+	It("fails for an async spec without a scheduling interval", func() {
+		Expect(mwu.CreateOrUpdateVRGManifestWork("options", "options-ns", clusterName, vrg, nil, false,
+			rmnutil.WithVRGAsync(&rmn.VRGAsyncSpec{}),
+		)).NotTo(Succeed())
+	})
+
+	It("fails for a VolSync spec without an async spec", func() {
+		Expect(mwu.CreateOrUpdateVRGManifestWork("options", "options-ns", clusterName, vrg, nil, false,
+			rmnutil.WithVRGSync(&rmn.VRGSyncSpec{}),
+			rmnutil.WithVRGVolSync(rmn.VolSyncSpec{
+				RDSpec: []rmn.VolSyncReplicationDestinationSpec{{ProtectedPVC: rmn.ProtectedPVC{Name: "pvc"}}},
+			}),
+		)).NotTo(Succeed())
+	})
+
+	It("sets the s3Profiles of the VRG defined in the ramen config", func() {
+		s3StoreProfiles := []rmn.S3StoreProfile{{S3ProfileName: "s3-east"}, {S3ProfileName: "s3-west"}}
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork("options", "options-ns", clusterName, vrg, nil, false,
+			rmnutil.WithVRGS3Profiles([]string{"s3-east", "s3-west"}, s3StoreProfiles),
+		)).To(Succeed())
+
+		mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName("options", "options-ns", rmnutil.MWTypeVRG), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		mwVRG, err := rmnutil.ExtractVRGFromManifestWork(mw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mwVRG.Spec.S3Profiles).To(Equal([]string{"s3-east", "s3-west"}))
+	})
+
+	It("propagates annotations to the VRG except reserved keys", func() {
+		Expect(mwu.CreateOrUpdateVRGManifestWork("options", "options-ns", clusterName, vrg, nil, false,
+			rmnutil.WithVRGPropagatedAnnotations(map[string]string{
+				"example.com/cost-center":                          "dr",
+				rmnutil.DRPCNameAnnotation:                         "options",
+				"ramendr.openshift.io/user":                        "user",
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			}),
+		)).To(Succeed())
+
+		mw, err := mwu.FindManifestWork(rmnutil.ManifestWorkName("options", "options-ns", rmnutil.MWTypeVRG), clusterName)
+		Expect(err).NotTo(HaveOccurred())
+
+		mwVRG, err := rmnutil.ExtractVRGFromManifestWork(mw)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mwVRG.GetAnnotations()).To(HaveKeyWithValue("example.com/cost-center", "dr"))
+		Expect(mwVRG.GetAnnotations()).NotTo(HaveKey(rmnutil.DRPCNameAnnotation))
+		Expect(mwVRG.GetAnnotations()).NotTo(HaveKey("ramendr.openshift.io/user"))
+		Expect(mwVRG.GetAnnotations()).NotTo(HaveKey("kubectl.kubernetes.io/last-applied-configuration"))
+	})
+
+	It("fails for an s3Profile not defined in the ramen config", func() {
+		err := mwu.CreateOrUpdateVRGManifestWork("options", "options-ns", clusterName, vrg, nil, false,
+			rmnutil.WithVRGS3Profiles([]string{"s3-east", "s3-north"}, []rmn.S3StoreProfile{{S3ProfileName: "s3-east"}}),
+		)
+		Expect(errors.Is(err, rmnutil.ErrS3ProfileNotDefined)).To(BeTrue())
+	})
+})
+
+var _ = Describe("ValidateVRGSpec", func() {
+	vrgWithSpec := func(mutate func(*rmn.VolumeReplicationGroupSpec)) *rmn.VolumeReplicationGroup {
+		vrg := &rmn.VolumeReplicationGroup{
+			ObjectMeta: metav1.ObjectMeta{Name: "validate", Namespace: "validate-ns"},
+			Spec:       validVRGSpec(),
+		}
+		mutate(&vrg.Spec)
+
+		return vrg
+	}
+
+	It("accepts a valid spec", func() {
+		Expect(rmnutil.ValidateVRGSpec(vrgWithSpec(func(*rmn.VolumeReplicationGroupSpec) {}))).To(Succeed())
+	})
+
+	It("rejects a spec with neither sync nor async mode", func() {
+		Expect(rmnutil.ValidateVRGSpec(vrgWithSpec(func(spec *rmn.VolumeReplicationGroupSpec) {
+			spec.Sync = nil
+		}))).NotTo(Succeed())
+	})
+
+	It("rejects an empty pvcSelector unless a recipe is referenced", func() {
+		Expect(rmnutil.ValidateVRGSpec(vrgWithSpec(func(spec *rmn.VolumeReplicationGroupSpec) {
+			spec.PVCSelector = metav1.LabelSelector{}
+		}))).NotTo(Succeed())
+
+		Expect(rmnutil.ValidateVRGSpec(vrgWithSpec(func(spec *rmn.VolumeReplicationGroupSpec) {
+			spec.PVCSelector = metav1.LabelSelector{}
+			spec.KubeObjectProtection = &rmn.KubeObjectProtectionSpec{RecipeRef: &rmn.RecipeRef{Name: "recipe"}}
+		}))).To(Succeed())
+	})
+
+	It("rejects a spec without s3Profiles", func() {
+		Expect(rmnutil.ValidateVRGSpec(vrgWithSpec(func(spec *rmn.VolumeReplicationGroupSpec) {
+			spec.S3Profiles = nil
+		}))).NotTo(Succeed())
+	})
+
+	It("refuses to ship an invalid VRG in a ManifestWork", func() {
+		mwu := newTestMWUtil()
+
+		vrg := vrgWithSpec(func(spec *rmn.VolumeReplicationGroupSpec) {
+			spec.S3Profiles = nil
+		})
+
+		validationFailures := func() float64 {
+			val, err := rmnutil.GetMetricValueWithLabels(rmnutil.VRGManifestGenerationFailuresTotal,
+				dto.MetricType_COUNTER,
+				map[string]string{rmnutil.VRGManifestFailureLabelKind: rmnutil.VRGManifestFailureKindValidation})
+			if err != nil {
+				return 0
+			}
+
+			return val
+		}
+
+		failures := validationFailures()
+
+		Expect(mwu.CreateOrUpdateVRGManifestWork("validate", "validate-ns", "mw-validate-cluster", *vrg,
+			nil, false)).NotTo(Succeed())
+		Expect(validationFailures()).To(Equal(failures + 1))
+	})
+})
@@ -31,6 +31,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	ramendrv1alpha1 "github.com/ramendr/ramen/api/v1alpha1"
 
@@ -146,6 +147,29 @@ func setupReconcilers(mgr ctrl.Manager, ramenConfig *ramendrv1alpha1.RamenConfig
 }
 
 func setupReconcilersHub(mgr ctrl.Manager) {
+	if err := (&rmnutil.MWUtil{
+		Client: mgr.GetClient(),
+		Ctx:    context.TODO(),
+		Log:    setupLog,
+	}).Preflight(); err != nil {
+		setupLog.Error(err, "hub preflight check failed")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		(&rmnutil.MWUtil{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Ctx:       ctx,
+			Log:       ctrl.Log.WithName("ManagedManifestWorksMetric"),
+		}).RefreshManagedManifestWorksMetricPeriodically(rmnutil.ManagedManifestWorksRefreshInterval)
+
+		return nil
+	})); err != nil {
+		setupLog.Error(err, "unable to add managed ManifestWorks metric refresher")
+		os.Exit(1)
+	}
+
 	if err := (&controllers.DRPolicyReconciler{
 		Client:            mgr.GetClient(),
 		APIReader:         mgr.GetAPIReader(),